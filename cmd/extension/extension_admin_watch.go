@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"regexp"
 	"strings"
@@ -55,6 +56,8 @@ var extensionAdminWatchCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var sources []asset.Source
 
+		projectRoot := os.Getenv("ONLISHOP_PROJECT_ROOT")
+
 		for _, extensionPath := range args[:len(args)-1] {
 			ext, err := extension.GetExtensionByFolder(extensionPath)
 			if err != nil {
@@ -63,6 +66,7 @@ var extensionAdminWatchCmd = &cobra.Command{
 					return err
 				}
 
+				projectRoot = extensionPath
 				sources = append(sources, extension.FindAssetSourcesOfProject(cmd.Context(), extensionPath, shopCfg)...)
 				continue
 			}
@@ -143,6 +147,14 @@ var extensionAdminWatchCmd = &cobra.Command{
 			}
 		}
 
+		broker := newLiveReloadBroker()
+
+		if projectRoot != "" {
+			go watchTwigTemplates(cmd.Context(), projectRoot, func() {
+				broker.Broadcast("reload")
+			})
+		}
+
 		fwd := forward.New(true)
 
 		redirect := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -156,6 +168,11 @@ var extensionAdminWatchCmd = &cobra.Command{
 				return
 			}
 
+			if req.URL.Path == "/__internal-admin-proxy/events" {
+				broker.ServeHTTP(w, req)
+				return
+			}
+
 			assetMatching := extensionAssetRegExp.FindAllString(req.URL.Path, -1)
 
 			if len(assetMatching) > 0 {
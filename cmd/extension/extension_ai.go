@@ -1,12 +1,89 @@
 package extension
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/internal/llm"
+	"github.com/onlishop/onlishop-cli/logging"
+)
 
 var extensionAiCmd = &cobra.Command{
 	Use:   "ai",
 	Short: "AI commands (experimental)",
 }
 
+// newLLMClientFromFlags creates the client configured by the persistent flags of the ai command.
+func newLLMClientFromFlags(cmd *cobra.Command) (llm.LLMClient, error) {
+	maxRetries, _ := cmd.Flags().GetInt("max-retries")
+	inputPrice, _ := cmd.Flags().GetFloat64("input-price")
+	outputPrice, _ := cmd.Flags().GetFloat64("output-price")
+
+	return llm.NewLLMClientFromConfig(llm.Config{
+		Provider:   cmd.Flag("provider").Value.String(),
+		BaseURL:    cmd.Flag("base-url").Value.String(),
+		APIKey:     os.Getenv("ONLISHOP_CLI_LLM_API_KEY"),
+		MaxRetries: maxRetries,
+		Pricing:    llm.Pricing{InputPerMillion: inputPrice, OutputPerMillion: outputPrice},
+	})
+}
+
+// generateWithFlags streams the response to stderr when requested by the --stream flag.
+func generateWithFlags(cmd *cobra.Command, client llm.LLMClient, prompt string, options *llm.LLMOptions) (string, error) {
+	if stream, _ := cmd.Flags().GetBool("stream"); !stream {
+		return client.Generate(cmd.Context(), prompt, options)
+	}
+
+	text, err := client.Stream(cmd.Context(), prompt, options, func(delta string) {
+		fmt.Fprint(os.Stderr, delta)
+	})
+
+	fmt.Fprintln(os.Stderr)
+
+	return text, err
+}
+
+// extractTwigFromResponse returns the content of the twig code block of the response, ignoring the thinking of reasoning models.
+func extractTwigFromResponse(text string) (string, bool) {
+	if thinkEndIndex := strings.Index(text, "</think>"); thinkEndIndex != -1 {
+		text = text[thinkEndIndex+len("</think>"):]
+	}
+
+	start := strings.Index(text, "```twig")
+	end := strings.LastIndex(text, "```")
+
+	if start == -1 || end <= start {
+		return "", false
+	}
+
+	return strings.TrimPrefix(text[start+7:end], "\n"), true
+}
+
+func unifiedDiff(fromFile, toFile, original, updated string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(original),
+		B:        difflib.SplitLines(updated),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+func logLLMUsage(cmd *cobra.Command, client llm.LLMClient) {
+	logging.FromContext(cmd.Context()).Infof("LLM usage: %s", client.Usage())
+}
+
 func init() {
+	extensionAiCmd.PersistentFlags().String("model", "gemma3:4b", "The model to use")
+	extensionAiCmd.PersistentFlags().String("provider", "ollama", "The provider to use (ollama, openai, openai-compatible, anthropic, gemini, openrouter)")
+	extensionAiCmd.PersistentFlags().String("base-url", "", "Base URL of the provider API, e.g. a self-hosted gateway. The API key can be set with ONLISHOP_CLI_LLM_API_KEY")
+	extensionAiCmd.PersistentFlags().Int("max-retries", llm.DefaultMaxRetries, "How often rate limited requests are retried")
+	extensionAiCmd.PersistentFlags().Float64("input-price", 0, "Price in USD per million input tokens to report the costs")
+	extensionAiCmd.PersistentFlags().Float64("output-price", 0, "Price in USD per million output tokens to report the costs")
+	extensionAiCmd.PersistentFlags().Bool("stream", false, "Print the responses while they are generated")
 	extensionRootCmd.AddCommand(extensionAiCmd)
 }
@@ -0,0 +1,278 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/shyim/go-version"
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/llm"
+	"github.com/onlishop/onlishop-cli/internal/verifier"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+	_ "github.com/onlishop/onlishop-cli/internal/verifier/twiglinter/admintwiglinter"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+const adminSystemPrompt = `
+You are a helper agent to help to upgrade Vue component templates of the Onlishop administration. I will give you the old and new template of an administration component and as third the template of an extension overriding it. Apply the changes happened between old and new template to the extension template.
+- Do only the necessary changes to the extension template.
+- Do only modify the content inside the blocks and dont add new blocks
+- If a block was renamed or removed in the new template, move the content to the matching new block
+- If in a {% block %} is {% parent %}, ignore it and dont modify the content of the block
+- Please also only output the modified extension template in a twig code block nothing more.
+`
+
+var extensionAiAdminUpgradeCmd = &cobra.Command{
+	Use:   "admin-upgrade [path] [old-onlishop-version] [new-onlishop-version]",
+	Short: "Upgrade administration component overrides using AI",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ext, err := extension.GetExtensionByFolder(args[0])
+		if err != nil {
+			return err
+		}
+
+		newOnlishopVersion, err := version.NewVersion(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid new onlishop version: %w", err)
+		}
+
+		toolCfg, err := verifier.ConvertExtensionToToolConfig(ext)
+		if err != nil {
+			return err
+		}
+
+		overrides, err := extension.FindAdminComponentOverrides(toolCfg.AdminDirectories)
+		if err != nil {
+			return err
+		}
+
+		if len(overrides) == 0 {
+			logging.FromContext(cmd.Context()).Infof("No administration component overrides found")
+			return nil
+		}
+
+		client, err := newLLMClientFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		defer logLLMUsage(cmd, client)
+
+		options := &llm.LLMOptions{
+			Model:        cmd.Flag("model").Value.String(),
+			SystemPrompt: adminSystemPrompt,
+		}
+
+		oldVersion, err := cloneOnlishopAdministration(cmd.Context(), args[1])
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := os.RemoveAll(oldVersion); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove old version directory: %v\n", err)
+			}
+		}()
+
+		newVersion, err := cloneOnlishopAdministration(cmd.Context(), args[2])
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := os.RemoveAll(newVersion); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove new version directory: %v\n", err)
+			}
+		}()
+
+		oldTemplates, err := indexAdminTemplates(oldVersion)
+		if err != nil {
+			return err
+		}
+
+		newTemplates, err := indexAdminTemplates(newVersion)
+		if err != nil {
+			return err
+		}
+
+		fixers := twiglinter.GetAdministrationFixers(newOnlishopVersion)
+		autoApprove, _ := cmd.Flags().GetBool("auto-approve")
+
+		for _, override := range overrides {
+			if override.Template == "" {
+				logging.FromContext(cmd.Context()).Infof("Skipping %s %s in %s, it has no template", override.Kind, override.Component, override.Script)
+				continue
+			}
+
+			content, err := os.ReadFile(override.Template)
+			if err != nil {
+				return err
+			}
+
+			// Deterministic fixes first, so the LLM only has to handle the remaining changes
+			updated, err := applyAdminTwigFixers(string(content), fixers)
+			if err != nil {
+				logging.FromContext(cmd.Context()).Warnf("Could not apply the admin twig fixers to %s: %v", override.Template, err)
+				updated = string(content)
+			}
+
+			oldTemplateText, oldErr := os.ReadFile(oldTemplates[override.Component])
+			newTemplateText, newErr := os.ReadFile(newTemplates[override.Component])
+
+			switch {
+			case oldErr != nil || newErr != nil:
+				logging.FromContext(cmd.Context()).Infof("Template of %s not found in both versions, applying only the deterministic fixes", override.Component)
+			case string(oldTemplateText) == string(newTemplateText):
+				logging.FromContext(cmd.Context()).Debugf("Template of %s did not change", override.Component)
+			default:
+				var str strings.Builder
+				str.WriteString(fmt.Sprintf("This was the old template of the component %s:\n", override.Component))
+				str.WriteString("```twig\n")
+				str.WriteString(string(oldTemplateText))
+				str.WriteString("\n```\n")
+				str.WriteString("and this is the new one:\n")
+				str.WriteString("```twig\n")
+				str.WriteString(string(newTemplateText))
+				str.WriteString("\n```\n")
+				str.WriteString("and this is my template:\n")
+				str.WriteString("```twig\n")
+				str.WriteString(updated)
+				str.WriteString("\n```")
+
+				startTime := time.Now()
+				logging.FromContext(cmd.Context()).Infof("Processing file %s", override.Template)
+
+				text, err := generateWithFlags(cmd, client, str.String(), options)
+				if err != nil {
+					return err
+				}
+
+				logging.FromContext(cmd.Context()).Infof("Processed file %s in %s", override.Template, time.Since(startTime))
+
+				if text, ok := extractTwigFromResponse(text); ok {
+					updated = text
+				}
+			}
+
+			if strings.TrimSpace(updated) == strings.TrimSpace(string(content)) {
+				continue
+			}
+
+			apply, err := reviewAdminTemplateChange(override.Template, string(content), updated, autoApprove)
+			if err != nil {
+				return err
+			}
+
+			if !apply {
+				continue
+			}
+
+			if err := os.WriteFile(override.Template, []byte(updated), os.ModePerm); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionAiAdminUpgradeCmd.Flags().Bool("auto-approve", false, "Apply all changes without asking")
+	extensionAiCmd.AddCommand(extensionAiAdminUpgradeCmd)
+}
+
+func applyAdminTwigFixers(content string, fixers []twiglinter.TwigFixer) (string, error) {
+	parsed, err := html.NewAdminParser(content)
+	if err != nil {
+		return "", err
+	}
+
+	for _, fixer := range fixers {
+		if err := fixer.Fix(parsed.Nodes); err != nil {
+			return "", err
+		}
+	}
+
+	return parsed.Dump(0), nil
+}
+
+// reviewAdminTemplateChange prints the diff of the template and asks whether it should be applied.
+func reviewAdminTemplateChange(file, original, updated string, autoApprove bool) (bool, error) {
+	diff, err := unifiedDiff(file, file, original, updated)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Println(diff)
+
+	if autoApprove {
+		return true, nil
+	}
+
+	var confirmed bool
+
+	if err := huh.NewConfirm().
+		Title(fmt.Sprintf("Apply the changes to %s?", filepath.Base(file))).
+		Value(&confirmed).
+		Run(); err != nil {
+		return false, err
+	}
+
+	return confirmed, nil
+}
+
+// indexAdminTemplates maps the component names to their templates, the templates are named like the component.
+func indexAdminTemplates(folder string) (map[string]string, error) {
+	templates := make(map[string]string)
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == "node_modules" || d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if name, ok := strings.CutSuffix(d.Name(), ".html.twig"); ok {
+			if _, exists := templates[name]; !exists {
+				templates[name] = path
+			}
+		}
+
+		return nil
+	})
+
+	return templates, err
+}
+
+func cloneOnlishopAdministration(ctx context.Context, version string) (string, error) {
+	tempDir, err := os.MkdirTemp(os.TempDir(), "onlishop")
+	if err != nil {
+		return "", err
+	}
+
+	git := exec.CommandContext(ctx, "git", "-c", "advice.detachedHead=false", "clone", "-q", "--branch", "v"+version, "https://github.com/onlishop/administration", tempDir, "--depth", "1")
+	output, err := git.CombinedOutput()
+	if err != nil {
+		logging.FromContext(ctx).Error(string(output))
+		return "", err
+	}
+
+	return tempDir, nil
+}
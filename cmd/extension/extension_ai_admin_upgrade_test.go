@@ -0,0 +1,23 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/shyim/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestApplyAdminTwigFixers(t *testing.T) {
+	fixers := twiglinter.GetAdministrationFixers(version.Must(version.NewVersion("6.7.0.0")))
+	require.NotEmpty(t, fixers)
+
+	updated, err := applyAdminTwigFixers(`{% block sw_product_detail_content %}
+<sw-alert variant="error">{{ $tc('sw-product.detail.errorMessage') }}</sw-alert>
+{% endblock %}`, fixers)
+	assert.NoError(t, err)
+	assert.Contains(t, updated, `<mt-banner variant="critical">`)
+	assert.NotContains(t, updated, "sw-alert")
+}
@@ -2,11 +2,13 @@ package extension
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -45,16 +47,62 @@ var extensionAiTwigUpgradeCmd = &cobra.Command{
 			return err
 		}
 
-		client, err := llm.NewLLMClient(cmd.Flag("provider").Value.String())
+		client, err := newLLMClientFromFlags(cmd)
 		if err != nil {
 			return err
 		}
 
+		defer logLLMUsage(cmd, client)
+
 		options := &llm.LLMOptions{
 			Model:        cmd.Flag("model").Value.String(),
 			SystemPrompt: systemPrompt,
 		}
 
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		stateFile := cmd.Flag("state-file").Value.String()
+		if stateFile == "" {
+			stateFile = filepath.Join(ext.GetPath(), ".twig-upgrade-state.json")
+		}
+
+		state, err := loadTwigUpgradeState(stateFile, args[1], args[2], dryRun)
+		if err != nil {
+			return err
+		}
+
+		if len(state.Processed) > 0 {
+			logging.FromContext(cmd.Context()).Infof("Resuming the upgrade, %d templates were already processed", len(state.Processed))
+		}
+
+		var patch *os.File
+
+		if dryRun {
+			// The paths in the patch are relative to the extension
+			patchFile := cmd.Flag("patch-file").Value.String()
+			if patchFile == "" {
+				patchFile = filepath.Join(ext.GetPath(), "twig-upgrade.patch")
+			}
+
+			if patchFile, err = filepath.Abs(patchFile); err != nil {
+				return err
+			}
+
+			// A resumed run continues the patch of the interrupted one
+			openFlags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+			if len(state.Processed) > 0 {
+				openFlags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+			}
+
+			if patch, err = os.OpenFile(patchFile, openFlags, 0o644); err != nil {
+				return fmt.Errorf("open patch file: %w", err)
+			}
+
+			defer func() {
+				_ = patch.Close()
+			}()
+		}
+
 		for _, sourceDirectory := range toolCfg.SourceDirectories {
 			twigFolder := path.Join(sourceDirectory, "Resources", "views", "storefront")
 
@@ -84,15 +132,7 @@ var extensionAiTwigUpgradeCmd = &cobra.Command{
 				}
 			}()
 
-			err = filepath.Walk(twigFolder, func(file string, info os.FileInfo, _ error) error {
-				if info.IsDir() {
-					return nil
-				}
-
-				if filepath.Ext(file) != ".twig" {
-					return nil
-				}
-
+			upgradeTemplate := func(file, relPath string) error {
 				content, err := os.ReadFile(file)
 				if err != nil {
 					return err
@@ -148,51 +188,173 @@ var extensionAiTwigUpgradeCmd = &cobra.Command{
 
 				logging.FromContext(cmd.Context()).Debugf("Input to LLM for file %s:\n%s\n", file, str.String())
 
-				text, err := client.Generate(cmd.Context(), str.String(), options)
+				text, err := generateWithFlags(cmd, client, str.String(), options)
 				if err != nil {
 					return err
 				}
 
 				logging.FromContext(cmd.Context()).Infof("Processed file %s in %s", file, time.Since(startTime))
 
-				if strings.Contains(text, "</think>") {
-					thinkEndIndex := strings.Index(text, "</think>")
-					if thinkEndIndex != -1 {
-						text = text[thinkEndIndex+len("</think>"):]
+				text, ok := extractTwigFromResponse(text)
+				if !ok {
+					return nil
+				}
+
+				contentStr := string(content)
+				if strings.TrimSpace(text) == strings.TrimSpace(contentStr) {
+					return nil
+				}
+
+				if err := validateTwigUpgrade(contentStr, text); err != nil {
+					logging.FromContext(cmd.Context()).Warnf("Rejected the upgrade of %s: %v", file, err)
+					return nil
+				}
+
+				if dryRun {
+					diff, err := unifiedDiff("a/"+relPath, "b/"+relPath, contentStr, text)
+					if err != nil {
+						return err
 					}
+
+					_, err = patch.WriteString(diff)
+
+					return err
 				}
 
-				start := strings.Index(text, "```twig")
-				end := strings.LastIndex(text, "```")
+				return os.WriteFile(file, []byte(text), os.ModePerm)
+			}
+
+			err = filepath.Walk(twigFolder, func(file string, info os.FileInfo, _ error) error {
+				if info.IsDir() {
+					return nil
+				}
 
-				if start == -1 || end == -1 {
+				if filepath.Ext(file) != ".twig" {
 					return nil
 				}
 
-				text = strings.TrimPrefix(text[start+7:end], "\n")
+				relPath, err := filepath.Rel(ext.GetPath(), file)
+				if err != nil {
+					return err
+				}
 
-				contentStr := string(content)
-				if strings.TrimSpace(text) == strings.TrimSpace(contentStr) {
+				relPath = filepath.ToSlash(relPath)
+
+				if state.IsProcessed(relPath) {
 					return nil
 				}
 
-				return os.WriteFile(file, []byte(text), os.ModePerm)
+				if err := upgradeTemplate(file, relPath); err != nil {
+					return err
+				}
+
+				return state.MarkProcessed(relPath)
 			})
 			if err != nil {
 				return err
 			}
 		}
 
+		if err := os.Remove(stateFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if dryRun {
+			logging.FromContext(cmd.Context()).Infof("Wrote the changes to %s, apply them with: git -C %s apply %s", patch.Name(), ext.GetPath(), patch.Name())
+		}
+
 		return nil
 	},
 }
 
 func init() {
-	extensionAiTwigUpgradeCmd.Flags().String("model", "gemma3:4b", "The model to use for the upgrade")
-	extensionAiTwigUpgradeCmd.Flags().String("provider", "ollama", "The provider to use for the upgrade")
+	extensionAiTwigUpgradeCmd.Flags().Bool("dry-run", false, "Write the changes as unified diff into the patch file instead of modifying the templates")
+	extensionAiTwigUpgradeCmd.Flags().String("patch-file", "", "Patch file written in dry-run mode (default: twig-upgrade.patch in the extension)")
+	extensionAiTwigUpgradeCmd.Flags().String("state-file", "", "File to track the processed templates, so an interrupted run can be resumed (default: .twig-upgrade-state.json in the extension)")
 	extensionAiCmd.AddCommand(extensionAiTwigUpgradeCmd)
 }
 
+// twigUpgradeState tracks the processed templates of a run, so it can be resumed after an interruption.
+type twigUpgradeState struct {
+	file       string
+	OldVersion string   `json:"oldVersion"`
+	NewVersion string   `json:"newVersion"`
+	DryRun     bool     `json:"dryRun"`
+	Processed  []string `json:"processed"`
+}
+
+// loadTwigUpgradeState reads the state of an interrupted run, a state of other versions or of the other mode is discarded.
+// A dry-run did not write the templates and a normal run did not write the patch, so their templates have to be processed again.
+func loadTwigUpgradeState(file, oldVersion, newVersion string, dryRun bool) (*twigUpgradeState, error) {
+	state := &twigUpgradeState{file: file, OldVersion: oldVersion, NewVersion: newVersion, DryRun: dryRun, Processed: []string{}}
+
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+
+	var previous twigUpgradeState
+	if err := json.Unmarshal(content, &previous); err != nil {
+		return nil, fmt.Errorf("decode state file %s: %w", file, err)
+	}
+
+	if previous.OldVersion == oldVersion && previous.NewVersion == newVersion && previous.DryRun == dryRun {
+		state.Processed = previous.Processed
+	}
+
+	return state, nil
+}
+
+func (s *twigUpgradeState) IsProcessed(file string) bool {
+	return slices.Contains(s.Processed, file)
+}
+
+// MarkProcessed records the template and saves the state immediately.
+func (s *twigUpgradeState) MarkProcessed(file string) error {
+	s.Processed = append(s.Processed, file)
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.file, content, 0o644); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+
+	return nil
+}
+
+// validateTwigUpgrade rejects upgraded templates which cannot be parsed or changed the block structure.
+func validateTwigUpgrade(original, upgraded string) error {
+	upgradedAst, err := twigparser.ParseTemplate(upgraded)
+	if err != nil {
+		return fmt.Errorf("the result cannot be parsed: %w", err)
+	}
+
+	originalAst, err := twigparser.ParseTemplate(original)
+	if err != nil {
+		return nil
+	}
+
+	if originalExtends, upgradedExtends := originalAst.Extends(), upgradedAst.Extends(); originalExtends != nil && (upgradedExtends == nil || upgradedExtends.Template != originalExtends.Template) {
+		return fmt.Errorf("the extended template %s was changed", originalExtends.Template)
+	}
+
+	originalBlocks := originalAst.BlockNames()
+	upgradedBlocks := upgradedAst.BlockNames()
+
+	if !slices.Equal(originalBlocks, upgradedBlocks) {
+		return fmt.Errorf("the blocks changed from [%s] to [%s]", strings.Join(originalBlocks, ", "), strings.Join(upgradedBlocks, ", "))
+	}
+
+	return nil
+}
+
 func cloneOnlishopStorefront(ctx context.Context, version string) (string, error) {
 	tempDir, err := os.MkdirTemp(os.TempDir(), "onlishop")
 	if err != nil {
@@ -0,0 +1,49 @@
+package extension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTwigUpgrade(t *testing.T) {
+	original := `{% sw_extends '@Storefront/storefront/base.html.twig' %}
+{% block base_header %}{% block base_header_inner %}<header></header>{% endblock %}{% endblock %}`
+
+	assert.NoError(t, validateTwigUpgrade(original, `{% sw_extends '@Storefront/storefront/base.html.twig' %}
+{% block base_header %}{% block base_header_inner %}<header class="header"></header>{% endblock %}{% endblock %}`))
+
+	assert.ErrorContains(t, validateTwigUpgrade(original, `{% sw_extends '@Storefront/storefront/base.html.twig' %}
+{% block base_header %}<header></header>{% endblock %}`), "the blocks changed")
+
+	assert.ErrorContains(t, validateTwigUpgrade(original, `{% sw_extends '@Storefront/storefront/page.html.twig' %}
+{% block base_header %}{% block base_header_inner %}<header></header>{% endblock %}{% endblock %}`), "extended template")
+
+	assert.ErrorContains(t, validateTwigUpgrade(original, `{% sw_extends '@Storefront/storefront/base.html.twig' %}
+{% block base_header %}{{ header`), "cannot be parsed")
+}
+
+func TestTwigUpgradeStateResume(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := loadTwigUpgradeState(stateFile, "6.5.0.0", "6.6.0.0", false)
+	require.NoError(t, err)
+	assert.False(t, state.IsProcessed("src/Resources/views/storefront/base.html.twig"))
+
+	require.NoError(t, state.MarkProcessed("src/Resources/views/storefront/base.html.twig"))
+
+	resumed, err := loadTwigUpgradeState(stateFile, "6.5.0.0", "6.6.0.0", false)
+	require.NoError(t, err)
+	assert.True(t, resumed.IsProcessed("src/Resources/views/storefront/base.html.twig"))
+
+	otherVersion, err := loadTwigUpgradeState(stateFile, "6.5.0.0", "6.7.0.0", false)
+	require.NoError(t, err)
+	assert.Empty(t, otherVersion.Processed)
+
+	// The interrupted run did not write a patch, a dry-run has to process the templates again
+	dryRun, err := loadTwigUpgradeState(stateFile, "6.5.0.0", "6.6.0.0", true)
+	require.NoError(t, err)
+	assert.Empty(t, dryRun.Processed)
+}
@@ -15,8 +15,11 @@ var extensionAssetBundleCmd = &cobra.Command{
 	Short: "Builds assets for extensions",
 	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		jobs, _ := cmd.Flags().GetInt("jobs")
+
 		assetCfg := extension.AssetBuildConfig{
 			OnlishopRoot: os.Getenv("ONLISHOP_PROJECT_ROOT"),
+			Jobs:         jobs,
 		}
 		validatedExtensions := make([]extension.Extension, 0)
 
@@ -59,4 +62,5 @@ var extensionAssetBundleCmd = &cobra.Command{
 
 func init() {
 	extensionRootCmd.AddCommand(extensionAssetBundleCmd)
+	extensionAssetBundleCmd.Flags().Int("jobs", 0, "Number of extensions to build concurrently with ESBuild (defaults to the number of CPUs)")
 }
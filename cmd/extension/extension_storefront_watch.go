@@ -0,0 +1,347 @@
+package extension
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NYTimes/gziphandler"
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/spf13/cobra"
+	"github.com/vulcand/oxy/v2/forward"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/asset"
+	"github.com/onlishop/onlishop-cli/internal/esbuild"
+	"github.com/onlishop/onlishop-cli/internal/system"
+	"github.com/onlishop/onlishop-cli/logging"
+	"github.com/onlishop/onlishop-cli/shop"
+)
+
+const (
+	storefrontLiveReloadPath   = "/__internal-storefront-proxy/live-reload.js"
+	storefrontLiveReloadEvents = "/__internal-storefront-proxy/events"
+)
+
+var (
+	storefrontThemeCSSRegExp = regexp.MustCompile(`(?m)/theme/[a-zA-Z0-9]+/css/all\.css$`)
+	storefrontScriptRegExp   = regexp.MustCompile(`(?m)/(?:theme/[a-zA-Z0-9]+|bundles/[a-z0-9-]+/storefront)/js/(?:[a-z0-9-]+/)?([a-z0-9-]+)\.js$`)
+)
+
+//go:embed static/storefront-live-reload.js
+var storefrontLiveReloadJS []byte
+
+var (
+	storefrontWatchListen = ""
+	storefrontWatchURL    = ""
+	storefrontWatchTheme  = ""
+)
+
+var extensionStorefrontWatchCmd = &cobra.Command{
+	Use:   "storefront-watch [path] [host]",
+	Short: "ESBuild powered Onlishop 6 Storefront watcher with CSS hot swapping",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var sources []asset.Source
+
+		onlishopRoot := os.Getenv("ONLISHOP_PROJECT_ROOT")
+
+		for _, extensionPath := range args[:len(args)-1] {
+			ext, err := extension.GetExtensionByFolder(extensionPath)
+			if err != nil {
+				shopCfg, err := shop.ReadConfig(path.Join(extensionPath, shop.DefaultConfigFileName()), true)
+				if err != nil {
+					return err
+				}
+
+				onlishopRoot = extensionPath
+				sources = append(sources, extension.FindAssetSourcesOfProject(cmd.Context(), extensionPath, shopCfg)...)
+				continue
+			}
+
+			sources = append(sources, extension.ConvertExtensionsToSources(cmd.Context(), []extension.Extension{ext})...)
+		}
+
+		allCfgs := extension.BuildAssetConfigFromExtensions(cmd.Context(), sources, extension.AssetBuildConfig{OnlishopRoot: onlishopRoot})
+		cfgs := allCfgs.FilterByStorefront().Not([]string{extension.StorefrontThemeName})
+
+		if len(cfgs) == 0 {
+			return fmt.Errorf("found nothing to compile")
+		}
+
+		if _, err := extension.InstallNodeModulesOfConfigs(cmd.Context(), cfgs, false); err != nil {
+			return err
+		}
+
+		listenSplit := strings.Split(storefrontWatchListen, ":")
+
+		if len(listenSplit) != 2 {
+			return fmt.Errorf("listen should contain a colon")
+		}
+
+		if len(storefrontWatchURL) == 0 {
+			storefrontWatchURL = "http://localhost:" + listenSplit[1]
+		}
+
+		browserUrl, err := url.Parse(storefrontWatchURL)
+		if err != nil {
+			return err
+		}
+
+		targetShopUrl, err := url.Parse(strings.TrimSuffix(args[len(args)-1], "/"))
+		if err != nil {
+			return err
+		}
+
+		broker := newLiveReloadBroker()
+		esbuildInstances := make(map[string]storefrontWatchExtension)
+		scssDirs := make([]string, 0, len(cfgs))
+
+		for name, entry := range cfgs {
+			technicalName := entry.TechnicalName
+
+			options := esbuild.NewAssetCompileOptionsStorefront(name, entry.BasePath, true)
+			options.ProductionMode = false
+			options.OnBuildEnd = func(result *api.BuildResult) {
+				if len(result.Errors) > 0 {
+					logging.FromContext(cmd.Context()).Errorf("Build of %s failed: %s", technicalName, result.Errors[0].Text)
+					return
+				}
+
+				logging.FromContext(cmd.Context()).Infof("Rebuilt storefront JavaScript of %s", technicalName)
+				broker.Broadcast("reload")
+			}
+
+			if onlishopRoot != "" {
+				extension.ApplyStorefrontResolution(&options, onlishopRoot)
+			}
+
+			esbuildContext, contextError := esbuild.Context(cmd.Context(), options)
+			if contextError != nil {
+				return contextError
+			}
+
+			if err := esbuildContext.Watch(api.WatchOptions{}); err != nil {
+				return err
+			}
+
+			watchServer, err := esbuildContext.Serve(api.ServeOptions{
+				Host: "127.0.0.1",
+			})
+			if err != nil {
+				return err
+			}
+
+			esbuildInstances[technicalName] = storefrontWatchExtension{
+				name:        name,
+				context:     esbuildContext,
+				watchServer: watchServer,
+			}
+
+			scssDirs = append(scssDirs, path.Join(entry.BasePath, "Resources", "app", "storefront", "src"))
+		}
+
+		theme := &storefrontThemeStylesheet{}
+
+		if onlishopRoot == "" {
+			logging.FromContext(cmd.Context()).Warnf("Cannot compile the theme without a Onlishop project, SCSS changes are not hot swapped. Pass the project folder as path or set ONLISHOP_PROJECT_ROOT")
+		} else {
+			theme.compile = func(ctx context.Context) (string, error) {
+				resolver, err := extension.NewThemeResolver(onlishopRoot, allCfgs)
+				if err != nil {
+					return "", err
+				}
+
+				resolved, err := resolver.Resolve(storefrontWatchTheme)
+				if err != nil {
+					return "", err
+				}
+
+				options := resolver.StyleOptions(resolved)
+				options.ProductionMode = false
+
+				return esbuild.CompileStorefrontTheme(ctx, options)
+			}
+
+			if err := theme.Rebuild(cmd.Context()); err != nil {
+				return err
+			}
+
+			go system.NewFileWatcher(system.ExistingDirs(scssDirs...), ".scss", ".sass", ".css").Watch(cmd.Context(), func(files []string) {
+				logging.FromContext(cmd.Context()).Infof("Detected changes of %s, recompiling theme", strings.Join(files, ", "))
+
+				if err := theme.Rebuild(cmd.Context()); err != nil {
+					logging.FromContext(cmd.Context()).Errorf("Cannot compile theme: %s", err.Error())
+					return
+				}
+
+				broker.Broadcast("css")
+			})
+		}
+
+		if onlishopRoot != "" {
+			go watchTwigTemplates(cmd.Context(), onlishopRoot, func() {
+				broker.Broadcast("reload")
+			})
+		}
+
+		shopOrigin := targetShopUrl.Scheme + schemeHostSeparator + targetShopUrl.Host
+		browserOrigin := browserUrl.Scheme + schemeHostSeparator + browserUrl.Host
+
+		// The shop has to see its own host, otherwise the sales channel domain does not match
+		fwd := forward.New(false)
+		fwd.ModifyResponse = func(resp *http.Response) error {
+			if location := resp.Header.Get("Location"); location != "" {
+				resp.Header.Set("Location", strings.Replace(location, shopOrigin, browserOrigin, 1))
+			}
+
+			if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+				return nil
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+
+			if err := resp.Body.Close(); err != nil {
+				return err
+			}
+
+			modified := injectStorefrontLiveReload(string(body), shopOrigin, browserOrigin)
+
+			resp.Body = io.NopCloser(strings.NewReader(modified))
+			resp.ContentLength = int64(len(modified))
+			resp.Header.Set("Content-Length", strconv.Itoa(len(modified)))
+
+			return nil
+		}
+
+		redirect := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			logging.FromContext(cmd.Context()).Debugf("Got request %s %s", req.Method, req.URL.Path)
+
+			if req.URL.Path == storefrontLiveReloadPath {
+				w.Header().Set("content-type", "application/javascript")
+				_, _ = w.Write(storefrontLiveReloadJS)
+
+				return
+			}
+
+			if req.URL.Path == storefrontLiveReloadEvents {
+				broker.ServeHTTP(w, req)
+				return
+			}
+
+			if css, ok := theme.CSS(); ok && storefrontThemeCSSRegExp.MatchString(req.URL.Path) {
+				w.Header().Set("content-type", "text/css")
+				w.Header().Set("cache-control", "no-cache")
+				_, _ = w.Write([]byte(css))
+
+				return
+			}
+
+			scriptMatch := storefrontScriptRegExp.FindStringSubmatch(req.URL.Path)
+
+			if len(scriptMatch) > 0 {
+				if ext, ok := esbuildInstances[scriptMatch[1]]; ok {
+					req.URL = &url.URL{Scheme: "http", Host: fmt.Sprintf("%s:%d", ext.watchServer.Hosts[0], ext.watchServer.Port), Path: "/extension.js"}
+					req.Host = req.URL.Host
+					req.RequestURI = req.URL.Path
+
+					fwd.ServeHTTP(w, req)
+					return
+				}
+			}
+
+			// We rewrite the HTML, so the shop should not compress it
+			req.Header.Del("Accept-Encoding")
+
+			req.URL = targetShopUrl
+			fwd.ServeHTTP(w, req)
+		})
+
+		wrapper, _ := gziphandler.GzipHandlerWithOpts(gziphandler.ContentTypes([]string{"application/json", "text/html", "text/javascript", "application/javascript", "text/css"}))
+
+		s := &http.Server{
+			Addr:              storefrontWatchListen,
+			Handler:           wrapper(redirect),
+			ReadHeaderTimeout: time.Second,
+		}
+		logging.FromContext(cmd.Context()).Infof("Storefront Watcher started at %s%s", browserUrl.String(), targetShopUrl.Path)
+		if err := s.ListenAndServe(); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionStorefrontWatchCmd)
+	extensionStorefrontWatchCmd.PersistentFlags().StringVar(&storefrontWatchListen, "listen", ":8080", "Listen (default :8080)")
+	extensionStorefrontWatchCmd.PersistentFlags().StringVar(&storefrontWatchURL, "external-url", "", "External reachable url for storefront watcher. Needed for reverse proxy setups")
+	extensionStorefrontWatchCmd.PersistentFlags().StringVar(&storefrontWatchTheme, "theme", extension.StorefrontThemeName, "Theme of the sales channel to compile")
+}
+
+// injectStorefrontLiveReload points all shop urls of the page to the watcher and adds the live reload script.
+func injectStorefrontLiveReload(body, shopOrigin, browserOrigin string) string {
+	body = strings.ReplaceAll(body, shopOrigin, browserOrigin)
+	body = strings.ReplaceAll(body, strings.ReplaceAll(shopOrigin, "/", "\\/"), strings.ReplaceAll(browserOrigin, "/", "\\/"))
+
+	script := fmt.Sprintf(`<script src="%s%s"></script>`, browserOrigin, storefrontLiveReloadPath)
+
+	if index := strings.LastIndex(body, "</body>"); index != -1 {
+		return body[:index] + script + body[index:]
+	}
+
+	return body + script
+}
+
+type storefrontWatchExtension struct {
+	name        string
+	context     api.BuildContext
+	watchServer api.ServeResult
+}
+
+// storefrontThemeStylesheet holds the last successfully compiled theme stylesheet.
+type storefrontThemeStylesheet struct {
+	mu      sync.RWMutex
+	css     string
+	built   bool
+	compile func(ctx context.Context) (string, error)
+}
+
+func (t *storefrontThemeStylesheet) Rebuild(ctx context.Context) error {
+	start := time.Now()
+
+	css, err := t.compile(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.css = css
+	t.built = true
+	t.mu.Unlock()
+
+	logging.FromContext(ctx).Infof("Compiled theme %s in %s", storefrontWatchTheme, time.Since(start).Round(time.Millisecond))
+
+	return nil
+}
+
+func (t *storefrontThemeStylesheet) CSS() (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.css, t.built
+}
@@ -0,0 +1,28 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectStorefrontLiveReload(t *testing.T) {
+	body := `<html><head><link rel="stylesheet" href="http://shop.test/theme/abc/css/all.css"></head><body><script>window.url = "http:\/\/shop.test\/"</script></body></html>`
+
+	modified := injectStorefrontLiveReload(body, "http://shop.test", "http://localhost:8080")
+
+	assert.Equal(t, `<html><head><link rel="stylesheet" href="http://localhost:8080/theme/abc/css/all.css"></head><body><script>window.url = "http:\/\/localhost:8080\/"</script><script src="http://localhost:8080/__internal-storefront-proxy/live-reload.js"></script></body></html>`, modified)
+}
+
+func TestInjectStorefrontLiveReloadWithoutBody(t *testing.T) {
+	modified := injectStorefrontLiveReload("<p>fragment</p>", "http://shop.test", "http://localhost:8080")
+
+	assert.Equal(t, `<p>fragment</p><script src="http://localhost:8080/__internal-storefront-proxy/live-reload.js"></script>`, modified)
+}
+
+func TestStorefrontScriptRegExp(t *testing.T) {
+	assert.Equal(t, "swag-example", storefrontScriptRegExp.FindStringSubmatch("/theme/9a11a759d278b4a55cb5e2c3414733c1/js/swag-example/swag-example.js")[1])
+	assert.Equal(t, "swag-example", storefrontScriptRegExp.FindStringSubmatch("/bundles/swagexample/storefront/js/swag-example.js")[1])
+	assert.Nil(t, storefrontScriptRegExp.FindStringSubmatch("/theme/9a11a759d278b4a55cb5e2c3414733c1/css/all.css"))
+	assert.True(t, storefrontThemeCSSRegExp.MatchString("/theme/9a11a759d278b4a55cb5e2c3414733c1/css/all.css"))
+}
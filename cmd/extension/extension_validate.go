@@ -6,9 +6,9 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/git"
 	"github.com/onlishop/onlishop-cli/internal/system"
 	"github.com/onlishop/onlishop-cli/internal/validation"
 	"github.com/onlishop/onlishop-cli/internal/verifier"
@@ -97,9 +97,24 @@ var extensionValidateCmd = &cobra.Command{
 		}
 
 		toolCfg.CheckAgainst = checkAgainst
-		result := verifier.NewCheck()
 
-		var gr errgroup.Group
+		if changedSince, _ := cmd.Flags().GetString("changed-since"); changedSince != "" {
+			if generateBaseline, _ := cmd.Flags().GetBool("generate-baseline"); generateBaseline {
+				return fmt.Errorf("--generate-baseline cannot be used with --changed-since, the baseline would only contain the results of the changed files")
+			}
+
+			if !stat.IsDir() {
+				return fmt.Errorf("--changed-since can only be used with an extension directory")
+			}
+
+			toolCfg.ChangedFiles, err = git.GetChangedFiles(cmd.Context(), path, changedSince)
+			if err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Checking %d files changed since %s", len(toolCfg.ChangedFiles), changedSince)
+		}
+		result := verifier.NewCheck()
 
 		tools := verifier.GetTools()
 
@@ -113,18 +128,37 @@ var extensionValidateCmd = &cobra.Command{
 			return err
 		}
 
-		for _, tool := range tools {
-			tool := tool
-			gr.Go(func() error {
-				return tool.Check(cmd.Context(), result, *toolCfg)
-			})
+		if err := tools.Check(cmd.Context(), result, *toolCfg); err != nil {
+			return err
 		}
 
-		if err := gr.Wait(); err != nil {
-			return err
+		result.RemoveByIdentifier(toolCfg.ValidationIgnores)
+
+		if toolCfg.OnlyChangedFiles() {
+			result.RemoveUnchanged(*toolCfg)
+		}
+
+		// The default baseline lives in the extension folder, or next to the zip
+		baselineRoot := path
+		if !stat.IsDir() {
+			baselineRoot = filepath.Dir(path)
+		}
+
+		baselineFile, _ := cmd.Flags().GetString("baseline")
+		generateBaseline, _ := cmd.Flags().GetBool("generate-baseline")
+		baselineFile = verifier.ResolveBaselineFile(baselineRoot, baselineFile, generateBaseline)
+
+		if generateBaseline {
+			return verifier.GenerateBaseline(cmd.Context(), result, baselineFile)
+		}
+
+		if baselineFile != "" {
+			if err := verifier.ApplyBaseline(cmd.Context(), result, baselineFile, *toolCfg); err != nil {
+				return err
+			}
 		}
 
-		return validation.DoCheckReport(result.RemoveByIdentifier(toolCfg.ValidationIgnores), reportingFormat)
+		return validation.DoCheckReport(result, reportingFormat)
 	},
 }
 
@@ -132,14 +166,17 @@ func init() {
 	extensionRootCmd.AddCommand(extensionValidateCmd)
 	extensionValidateCmd.PersistentFlags().Bool("full", false, "Run full validation including PHPStan, ESLint and Stylelint")
 	extensionValidateCmd.PersistentFlags().Bool("store-compliance", false, "Runs specific store compliance checks")
-	extensionValidateCmd.PersistentFlags().String("reporter", "", "Reporting format (summary, json, github, junit, markdown)")
+	extensionValidateCmd.PersistentFlags().String("reporter", "", "Reporting format (summary, json, github, junit, markdown, sarif)")
 	extensionValidateCmd.PersistentFlags().String("check-against", "highest", "Check against Onlishop Version (highest, lowest)")
 	extensionValidateCmd.PersistentFlags().String("only", "", "Run only specific tools by name (comma-separated, e.g. phpstan,eslint)")
 	extensionValidateCmd.PersistentFlags().String("exclude", "", "Exclude specific tools by name (comma-separated, e.g. phpstan,eslint)")
+	extensionValidateCmd.PersistentFlags().String("changed-since", "", "Only check files changed since the given git ref")
+	extensionValidateCmd.PersistentFlags().String("baseline", "", "Only fail on results which are not part of this baseline file, defaults to .onlishop-validation-baseline.json in the extension root when it exists")
+	extensionValidateCmd.PersistentFlags().Bool("generate-baseline", false, "Write all current results into the baseline file instead of reporting them, defaults to .onlishop-validation-baseline.json in the extension root")
 	extensionValidateCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		reporter, _ := cmd.Flags().GetString("reporter")
-		if reporter != "summary" && reporter != "json" && reporter != "github" && reporter != "junit" && reporter != "markdown" && reporter != "" {
-			return fmt.Errorf("invalid reporter format: %s. Must be either 'summary', 'json', 'github', 'junit' or 'markdown'", reporter)
+		if reporter != "summary" && reporter != "json" && reporter != "github" && reporter != "junit" && reporter != "markdown" && reporter != "sarif" && reporter != "" {
+			return fmt.Errorf("invalid reporter format: %s. Must be either 'summary', 'json', 'github', 'junit', 'markdown' or 'sarif'", reporter)
 		}
 
 		mode, _ := cmd.Flags().GetString("check-against")
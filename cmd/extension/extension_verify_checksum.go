@@ -0,0 +1,56 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/table"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+var extensionVerifyChecksumCmd = &cobra.Command{
+	Use:   "verify-checksum [path]",
+	Short: "Verifies the files of an extracted extension against its checksum.json",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		extensionPath := "."
+
+		if len(args) == 1 {
+			extensionPath = args[0]
+		}
+
+		extensionPath, err := filepath.Abs(extensionPath)
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		ext, err := extension.GetExtensionByFolder(extensionPath)
+		if err != nil {
+			return fmt.Errorf("verify-checksum: cannot open extension %w", err)
+		}
+
+		result, err := extension.VerifyChecksumJSON(extensionPath, ext)
+		if err != nil {
+			return err
+		}
+
+		if result.Valid() {
+			logging.FromContext(cmd.Context()).Infof("All files match the checksum.json of version %s", result.ExtensionVersion)
+			return nil
+		}
+
+		if err := table.RenderTable(os.Stdout, []string{"Change", "File"}, result.Rows()); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%d files differ from the checksum.json of version %s", len(result.Rows()), result.ExtensionVersion)
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionVerifyChecksumCmd)
+}
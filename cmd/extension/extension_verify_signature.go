@@ -0,0 +1,81 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/signing"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+var extensionVerifySignatureCmd = &cobra.Command{
+	Use:   "verify-signature [zip]",
+	Short: "Verifies the signature of an extension zip and its checksum.json",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		publicKeyFlag, _ := cmd.Flags().GetString("public-key")
+
+		zipFile, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot find path: %w", err)
+		}
+
+		publicKey, err := extension.LoadPublicKey(publicKeyFlag)
+		if err != nil {
+			return err
+		}
+
+		if err := extension.VerifyZipSignature(zipFile, publicKey); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Signature of %s is valid and was created with key %s", filepath.Base(zipFile), publicKey.KeyID())
+
+		return nil
+	},
+}
+
+var extensionGenerateSigningKeyCmd = &cobra.Command{
+	Use:   "generate-signing-key [name]",
+	Short: "Generates an ed25519 key pair to sign extension zips, the public key and signatures are minisign compatible",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := "onlishop-extension"
+
+		if len(args) == 1 {
+			name = args[0]
+		}
+
+		if _, err := os.Stat(name + ".key"); err == nil {
+			return fmt.Errorf("secret key %s.key already exists", name)
+		}
+
+		publicKey, secretKey, err := signing.GenerateKey()
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(name+".key", []byte(secretKey.String()), 0o600); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(name+".pub", []byte(publicKey.String()), 0o644); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Generated key %s. Keep %s.key secret and distribute %s.pub to verify your extensions", publicKey.KeyID(), name, name)
+
+		return nil
+	},
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionVerifySignatureCmd)
+	extensionRootCmd.AddCommand(extensionGenerateSigningKeyCmd)
+	extensionVerifySignatureCmd.Flags().String("public-key", "", "Public key file or the public key itself")
+	_ = extensionVerifySignatureCmd.MarkFlagRequired("public-key")
+}
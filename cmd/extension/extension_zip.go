@@ -7,11 +7,13 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"time"
 
 	cp "github.com/otiai10/copy"
 	"github.com/spf13/cobra"
 
 	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/sbom"
 	"github.com/onlishop/onlishop-cli/internal/validation"
 	"github.com/onlishop/onlishop-cli/logging"
 )
@@ -54,7 +56,13 @@ var extensionZipCmd = &cobra.Command{
 			return err
 		}
 
+		compareWith, _ := cmd.Flags().GetString("compare")
+
 		for _, file := range existingFiles {
+			if compareWith != "" && isSamePath(file, compareWith) {
+				continue
+			}
+
 			err = os.Remove(file)
 			if err != nil {
 				return fmt.Errorf("remove existing file: %w", err)
@@ -85,6 +93,10 @@ var extensionZipCmd = &cobra.Command{
 			_ = os.RemoveAll(path)
 		}(tempDir)
 
+		reproducible, _ := cmd.Flags().GetBool("reproducible")
+		reproducible = reproducible || extCfg.Build.Zip.Pack.Reproducible
+		buildTime := time.Now()
+
 		var tag string
 
 		// Extract files using strategy
@@ -104,6 +116,12 @@ var extensionZipCmd = &cobra.Command{
 			logging.FromContext(cmd.Context()).Infof("Checking out %s using Git", tag)
 		}
 
+		if reproducible {
+			if buildTime, err = extension.SourceDateEpoch(cmd.Context(), extPath, tag); err != nil {
+				return fmt.Errorf("determine build time for reproducible zip: %w", err)
+			}
+		}
+
 		// User input wins
 		if len(branch) > 0 {
 			tag = branch
@@ -159,6 +177,25 @@ var extensionZipCmd = &cobra.Command{
 			}
 		}
 
+		if sbomFormat, _ := cmd.Flags().GetString("sbom"); sbomFormat != "" || extCfg.Build.Zip.Sbom.Enabled {
+			if sbomFormat == "" {
+				sbomFormat = extCfg.Build.Zip.Sbom.Format
+			}
+
+			if sbomFormat == "" {
+				sbomFormat = sbom.FormatCycloneDX
+			}
+
+			extVersion, err := tempExt.GetVersion()
+			if err != nil {
+				return fmt.Errorf("get version: %w", err)
+			}
+
+			if _, err := extension.GenerateSBOM(cmd.Context(), extDir, name, extVersion.String(), sbomFormat, buildTime); err != nil {
+				return fmt.Errorf("generate sbom: %w", err)
+			}
+		}
+
 		// Cleanup not wanted files
 		if err := extension.CleanupExtensionFolder(extDir, extCfg.Build.Zip.Pack.Excludes.Paths); err != nil {
 			return fmt.Errorf("cleanup package: %w", err)
@@ -207,17 +244,60 @@ var extensionZipCmd = &cobra.Command{
 			return fmt.Errorf("before hooks pack: %w", err)
 		}
 
+		signingKey, err := extension.LoadSigningKey(ext)
+		if err != nil {
+			return fmt.Errorf("load signing key: %w", err)
+		}
+
 		// Generate checksums.json file before creating the zip
 		if err := extension.GenerateChecksumJSON(cmd.Context(), extDir, ext); err != nil {
 			return fmt.Errorf("generate checksum.json: %w", err)
 		}
 
-		if err := extension.CreateZip(tempDir, fileName); err != nil {
+		if _, err := os.Stat(path.Join(extDir, "checksum.json")); err == nil && signingKey != nil {
+			if err := extension.SignFileAt(signingKey, path.Join(extDir, "checksum.json"), buildTime); err != nil {
+				return fmt.Errorf("sign checksum.json: %w", err)
+			}
+		}
+
+		buildFileName := zipBuildPath(fileName, compareWith)
+
+		if reproducible {
+			err = extension.CreateReproducibleZip(tempDir, buildFileName, buildTime)
+		} else {
+			err = extension.CreateZip(tempDir, buildFileName)
+		}
+
+		if err != nil {
 			return fmt.Errorf("create zip file: %w", err)
 		}
 
+		if compareWith != "" {
+			if err := compareZipHashes(cmd.Context(), buildFileName, compareWith); err != nil {
+				if buildFileName != fileName {
+					_ = os.Remove(buildFileName)
+				}
+
+				return err
+			}
+		}
+
+		if buildFileName != fileName {
+			if err := os.Rename(buildFileName, fileName); err != nil {
+				return fmt.Errorf("move zip file: %w", err)
+			}
+		}
+
 		logging.FromContext(cmd.Context()).Infof("Created file %s", fileName)
 
+		if signingKey != nil {
+			if err := extension.SignFileAt(signingKey, fileName, buildTime); err != nil {
+				return fmt.Errorf("sign zip file: %w", err)
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Signed file %s with key %s", fileName, signingKey.Public().KeyID())
+		}
+
 		return nil
 	},
 }
@@ -231,9 +311,62 @@ func init() {
 	extensionZipCmd.Flags().String("overwrite-version", "", "Change the extension version to this value")
 	extensionZipCmd.Flags().String("output-directory", "", "Output directory for the zip file")
 	extensionZipCmd.Flags().String("git-commit", "", "Commit Hash / Tag to use")
+	extensionZipCmd.Flags().String("sbom", "", "Adds a SBOM of the bundled dependencies in the given format (cyclonedx, spdx)")
+	extensionZipCmd.Flags().Bool("reproducible", false, "Create a byte identical zip for the same commit, uses SOURCE_DATE_EPOCH or the commit date as file time")
+	extensionZipCmd.Flags().String("compare", "", "Fail when the created zip differs from the given zip, e.g. to verify a rebuild of a released tag")
 	extensionZipCmd.Flags().String("filename", "", "Name of the zip file, if not set it will be generated from the extension name and tag")
 }
 
+// zipBuildPath returns the path the zip is created at. When the zip is compared with the file it replaces,
+// it is created next to it, so the existing zip is not overwritten before the comparison.
+func zipBuildPath(fileName, compareWith string) string {
+	if compareWith == "" {
+		return fileName
+	}
+
+	if !isSamePath(fileName, compareWith) {
+		return fileName
+	}
+
+	return fileName + ".rebuild"
+}
+
+// isSamePath reports whether both paths point to the same file, independent of how they are spelled
+func isSamePath(a, b string) bool {
+	aAbs, err := filepath.Abs(a)
+	if err != nil {
+		return false
+	}
+
+	bAbs, err := filepath.Abs(b)
+	if err != nil {
+		return false
+	}
+
+	return aAbs == bAbs
+}
+
+// compareZipHashes fails when the sha256 hashes of both zips differ.
+func compareZipHashes(ctx context.Context, created, expected string) error {
+	createdHash, err := extension.FileSHA256(created)
+	if err != nil {
+		return fmt.Errorf("hash zip file: %w", err)
+	}
+
+	expectedHash, err := extension.FileSHA256(expected)
+	if err != nil {
+		return fmt.Errorf("hash zip file: %w", err)
+	}
+
+	if createdHash != expectedHash {
+		return fmt.Errorf("the rebuilt zip %s (sha256 %s) differs from %s (sha256 %s)", created, createdHash, expected, expectedHash)
+	}
+
+	logging.FromContext(ctx).Infof("The rebuilt zip matches %s (sha256 %s)", expected, createdHash)
+
+	return nil
+}
+
 func getStringOnStringError(val string, _ error) string {
 	return val
 }
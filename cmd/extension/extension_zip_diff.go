@@ -0,0 +1,104 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/table"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+var extensionZipDiffCmd = &cobra.Command{
+	Use:   "zip-diff [old-zip] [new-zip]",
+	Short: "Shows the changes of the shipped files between two extension zips",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldExt, err := extension.GetExtensionByZip(args[0])
+		if err != nil {
+			return fmt.Errorf("zip-diff: cannot open %s: %w", args[0], err)
+		}
+
+		defer func() {
+			_ = os.RemoveAll(filepath.Dir(oldExt.GetPath()))
+		}()
+
+		newExt, err := extension.GetExtensionByZip(args[1])
+		if err != nil {
+			return fmt.Errorf("zip-diff: cannot open %s: %w", args[1], err)
+		}
+
+		defer func() {
+			_ = os.RemoveAll(filepath.Dir(newExt.GetPath()))
+		}()
+
+		diff, err := extension.DiffExtensions(oldExt, newExt)
+		if err != nil {
+			return err
+		}
+
+		logger := logging.FromContext(cmd.Context())
+
+		logger.Infof("Version: %s -> %s", diff.OldVersion, diff.NewVersion)
+
+		if diff.OldConstraint != diff.NewConstraint {
+			logger.Warnf("Onlishop version constraint changed: %s -> %s", diff.OldConstraint, diff.NewConstraint)
+		}
+
+		if len(diff.Requirements) > 0 {
+			rows := make([][]string, 0, len(diff.Requirements))
+			for _, requirement := range diff.Requirements {
+				rows = append(rows, []string{requirement.Package, orDash(requirement.Old), orDash(requirement.New)})
+			}
+
+			if err := table.RenderTable(os.Stdout, []string{"Composer requirement", "Old", "New"}, rows); err != nil {
+				return err
+			}
+		}
+
+		if len(diff.Files) > 0 {
+			rows := make([][]string, 0, len(diff.Files))
+			for _, file := range diff.Files {
+				rows = append(rows, []string{file.Change, file.Path, formatSizeDelta(file.NewSize - file.OldSize)})
+			}
+
+			if err := table.RenderTable(os.Stdout, []string{"Change", "File", "Size"}, rows); err != nil {
+				return err
+			}
+		} else {
+			logger.Infof("The shipped files are identical")
+		}
+
+		for _, file := range diff.NewPHPFiles() {
+			logger.Warnf("New PHP file: %s", file)
+		}
+
+		logger.Infof("Asset size: %d -> %d bytes (%s)", diff.OldAssetSize, diff.NewAssetSize, formatSizeDelta(diff.NewAssetSize-diff.OldAssetSize))
+
+		return nil
+	},
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func formatSizeDelta(delta int64) string {
+	if delta > 0 {
+		return "+" + strconv.FormatInt(delta, 10) + " B"
+	}
+
+	return strconv.FormatInt(delta, 10) + " B"
+}
+
+func init() {
+	extensionRootCmd.AddCommand(extensionZipDiffCmd)
+}
@@ -0,0 +1,39 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipBuildPathComparedWithItself(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	require.NoError(t, os.WriteFile("MyExt-1.0.0.zip", []byte("released"), os.ModePerm))
+
+	assert.Equal(t, "MyExt-1.0.0.zip", zipBuildPath("MyExt-1.0.0.zip", ""))
+	assert.Equal(t, "MyExt-1.0.0.zip", zipBuildPath("MyExt-1.0.0.zip", "MyExt-0.9.0.zip"))
+
+	buildPath := zipBuildPath("MyExt-1.0.0.zip", filepath.Join(dir, "MyExt-1.0.0.zip"))
+	assert.NotEqual(t, "MyExt-1.0.0.zip", buildPath)
+
+	require.NoError(t, os.WriteFile(buildPath, []byte("rebuilt"), os.ModePerm))
+
+	assert.ErrorContains(t, compareZipHashes(t.Context(), buildPath, "MyExt-1.0.0.zip"), "differs from")
+
+	require.NoError(t, os.WriteFile(buildPath, []byte("released"), os.ModePerm))
+	assert.NoError(t, compareZipHashes(t.Context(), buildPath, "MyExt-1.0.0.zip"))
+}
+
+func TestIsSamePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	assert.True(t, isSamePath("MyExt-1.0.0.zip", filepath.Join(dir, "MyExt-1.0.0.zip")))
+	assert.True(t, isSamePath("MyExt-1.0.0.zip", "./sub/../MyExt-1.0.0.zip"))
+	assert.False(t, isSamePath("MyExt-1.0.0.zip", "MyExt-0.9.0.zip"))
+}
@@ -0,0 +1,97 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/system"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+// liveReloadBroker sends server-sent events to all connected browsers of a watcher.
+type liveReloadBroker struct {
+	mu      sync.Mutex
+	clients map[chan string]struct{}
+}
+
+func newLiveReloadBroker() *liveReloadBroker {
+	return &liveReloadBroker{clients: make(map[chan string]struct{})}
+}
+
+// Broadcast sends the event to all connected browsers, slow clients miss the event instead of blocking the watcher.
+func (b *liveReloadBroker) Broadcast(event string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for client := range b.clients {
+		select {
+		case client <- event:
+		default:
+		}
+	}
+}
+
+func (b *liveReloadBroker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan string, 10)
+
+	b.mu.Lock()
+	b.clients[client] = struct{}{}
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.clients, client)
+		b.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case event := <-client:
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: {}\n\n", event); err != nil {
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// watchTwigTemplates clears the twig cache of the project and calls onReload whenever a template of an extension changes.
+func watchTwigTemplates(ctx context.Context, projectRoot string, onReload func()) {
+	dirs := system.ExistingDirs(extension.TemplateDirs(ctx, projectRoot)...)
+
+	if len(dirs) == 0 {
+		return
+	}
+
+	logging.FromContext(ctx).Infof("Watching templates in %d directories", len(dirs))
+
+	system.NewFileWatcher(dirs, ".twig").Watch(ctx, func(files []string) {
+		logging.FromContext(ctx).Infof("Detected template changes of %s, clearing twig cache", strings.Join(files, ", "))
+
+		if err := extension.ClearTwigCache(projectRoot); err != nil {
+			logging.FromContext(ctx).Errorf("Cannot clear twig cache: %s", err.Error())
+			return
+		}
+
+		onReload()
+	})
+}
@@ -34,4 +34,6 @@ for (const bundleName of Object.keys(bundles)) {
 
         location.reload()
     })
-}
\ No newline at end of file
+}
+
+new EventSource('/__internal-admin-proxy/events').addEventListener('reload', () => location.reload())
@@ -0,0 +1,22 @@
+(() => {
+    const events = new EventSource('/__internal-storefront-proxy/events');
+
+    events.addEventListener('css', () => {
+        for (const link of document.querySelectorAll('link[rel="stylesheet"]')) {
+            const url = new URL(link.href);
+
+            if (url.host !== location.host || !url.pathname.endsWith('/css/all.css')) {
+                continue;
+            }
+
+            url.searchParams.set('live-reload', Math.random().toString(36).slice(2));
+
+            const next = link.cloneNode();
+            next.href = url.toString();
+            next.onload = () => link.remove();
+            link.parentNode.insertBefore(next, link.nextSibling);
+        }
+    });
+
+    events.addEventListener('reload', () => location.reload());
+})();
@@ -9,6 +9,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"dario.cat/mergo"
 	"github.com/spf13/cobra"
@@ -105,6 +106,16 @@ var projectCI = &cobra.Command{
 			logging.FromContext(cmd.Context()).Infof("Skipping composer install")
 		}
 
+		if sbomFormat, _ := cmd.Flags().GetString("sbom"); sbomFormat != "" {
+			sbomSection := ci.Default.Section(cmd.Context(), "Generating SBOM")
+
+			if _, err := extension.GenerateSBOM(cmd.Context(), args[0], filepath.Base(args[0]), os.Getenv("COMPOSER_ROOT_VERSION"), sbomFormat, time.Now()); err != nil {
+				return err
+			}
+
+			sbomSection.End(cmd.Context())
+		}
+
 		lookingForExtensionsSection := ci.Default.Section(cmd.Context(), "Looking for extensions")
 
 		sources := extension.FindAssetSourcesOfProject(cmd.Context(), args[0], shopCfg)
@@ -116,6 +127,8 @@ var projectCI = &cobra.Command{
 
 		lookingForExtensionsSection.End(cmd.Context())
 
+		jobs, _ := cmd.Flags().GetInt("jobs")
+
 		assetCfg := extension.AssetBuildConfig{
 			CleanupNodeModules:           true,
 			OnlishopRoot:                 args[0],
@@ -126,6 +139,7 @@ var projectCI = &cobra.Command{
 			ForceExtensionBuild:          convertForceExtensionBuild(shopCfg.Build.ForceExtensionBuild),
 			ForceAdminBuild:              shopCfg.Build.ForceAdminBuild,
 			KeepNodeModules:              shopCfg.Build.KeepNodeModules,
+			Jobs:                         jobs,
 		}
 
 		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg); err != nil {
@@ -297,6 +311,8 @@ func prepareComposerAuth(ctx context.Context, root string) (string, error) {
 func init() {
 	projectRootCmd.AddCommand(projectCI)
 	projectCI.PersistentFlags().Bool("with-dev-dependencies", false, "Install dev dependencies")
+	projectCI.PersistentFlags().String("sbom", "", "Writes a SBOM of the installed dependencies in the given format (cyclonedx, spdx) into the project")
+	projectCI.PersistentFlags().Int("jobs", 0, "Number of extensions to build concurrently with ESBuild (defaults to the number of CPUs)")
 }
 
 func commandWithRoot(cmd *exec.Cmd, root string) *exec.Cmd {
@@ -46,6 +46,7 @@ var projectAdminBuildCmd = &cobra.Command{
 		}
 
 		forceInstall, _ := cmd.PersistentFlags().GetBool("force-install-dependencies")
+		jobs, _ := cmd.PersistentFlags().GetInt("jobs")
 
 		onlishopConstraint, err := extension.GetOnlishopProjectConstraint(projectRoot)
 		if err != nil {
@@ -58,6 +59,7 @@ var projectAdminBuildCmd = &cobra.Command{
 			OnlishopVersion:        onlishopConstraint,
 			NPMForceInstall:        forceInstall,
 			ForceAdminBuild:        shopCfg.Build.ForceAdminBuild,
+			Jobs:                   jobs,
 		}
 
 		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg); err != nil {
@@ -80,4 +82,5 @@ func init() {
 	projectAdminBuildCmd.PersistentFlags().String("only-extensions", "", "Only watch the given extensions (comma separated)")
 	projectAdminBuildCmd.PersistentFlags().String("skip-extensions", "", "Skips the given extensions (comma separated)")
 	projectAdminBuildCmd.PersistentFlags().Bool("only-custom-static-extensions", false, "Only build extensions from custom/static-plugins directory")
+	projectAdminBuildCmd.PersistentFlags().Int("jobs", 0, "Number of extensions to build concurrently with ESBuild (defaults to the number of CPUs)")
 }
@@ -34,6 +34,7 @@ var projectExtensionUploadCmd = &cobra.Command{
 
 		doLifecycleEvents, _ := cmd.PersistentFlags().GetBool("activate")
 		increaseVersionBeforeUpload, _ := cmd.PersistentFlags().GetBool("increase-version")
+		publicKeyFlag, _ := cmd.PersistentFlags().GetString("public-key")
 
 		path, err := filepath.Abs(args[0])
 		if err != nil {
@@ -45,6 +46,23 @@ var projectExtensionUploadCmd = &cobra.Command{
 			return fmt.Errorf("cannot find path: %w", err)
 		}
 
+		if publicKeyFlag != "" {
+			if stat.IsDir() {
+				return fmt.Errorf("the signature can only be verified for zip files")
+			}
+
+			publicKey, err := extension.LoadPublicKey(publicKeyFlag)
+			if err != nil {
+				return err
+			}
+
+			if err := extension.VerifyZipSignature(path, publicKey); err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Verified signature with key %s", publicKey.KeyID())
+		}
+
 		var ext extension.Extension
 
 		isFolder := true
@@ -347,4 +365,5 @@ func init() {
 	projectExtensionCmd.AddCommand(projectExtensionUploadCmd)
 	projectExtensionUploadCmd.PersistentFlags().Bool("activate", false, "Installs, Activates, Updates the extension")
 	projectExtensionUploadCmd.PersistentFlags().Bool("increase-version", false, "Increases extension version before uploading")
+	projectExtensionUploadCmd.PersistentFlags().String("public-key", "", "Verifies the signature of the zip with this public key file or key before uploading")
 }
@@ -0,0 +1,81 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/internal/table"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+var projectExtensionVerifyCmd = &cobra.Command{
+	Use:   "verify [name]",
+	Short: "Verifies the files of the project extensions against their checksum.json",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := findClosestOnlishopProject()
+		if err != nil {
+			return err
+		}
+
+		extensions := extension.FindExtensionsFromProject(cmd.Context(), projectRoot)
+
+		sort.Slice(extensions, func(i, j int) bool {
+			return extensions[i].GetPath() < extensions[j].GetPath()
+		})
+
+		rows := make([][]string, 0)
+		verified := 0
+
+		for _, ext := range extensions {
+			name, err := ext.GetName()
+			if err != nil {
+				continue
+			}
+
+			if len(args) > 0 && !slices.Contains(args, name) {
+				continue
+			}
+
+			if _, err := os.Stat(filepath.Join(ext.GetPath(), "checksum.json")); os.IsNotExist(err) {
+				logging.FromContext(cmd.Context()).Debugf("Skipping %s as it has no checksum.json", name)
+				continue
+			}
+
+			result, err := extension.VerifyChecksumJSON(ext.GetPath(), ext)
+			if err != nil {
+				return fmt.Errorf("cannot verify %s: %w", name, err)
+			}
+
+			verified++
+
+			for _, row := range result.Rows() {
+				rows = append(rows, append([]string{name}, row...))
+			}
+		}
+
+		if verified == 0 {
+			return fmt.Errorf("found no extensions with a checksum.json")
+		}
+
+		if len(rows) == 0 {
+			logging.FromContext(cmd.Context()).Infof("All files of %d extensions match their checksum.json", verified)
+			return nil
+		}
+
+		if err := table.RenderTable(os.Stdout, []string{"Extension", "Change", "File"}, rows); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%d files differ from the checksum.json of their extension", len(rows))
+	},
+}
+
+func init() {
+	projectExtensionCmd.AddCommand(projectExtensionVerifyCmd)
+}
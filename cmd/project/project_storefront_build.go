@@ -46,6 +46,10 @@ var projectStorefrontBuildCmd = &cobra.Command{
 		}
 
 		forceInstall, _ := cmd.PersistentFlags().GetBool("force-install-dependencies")
+		jobs, _ := cmd.PersistentFlags().GetInt("jobs")
+		forceESBuild, _ := cmd.PersistentFlags().GetBool("esbuild")
+		themeOutput, _ := cmd.PersistentFlags().GetString("theme-output")
+		themeName, _ := cmd.PersistentFlags().GetString("theme")
 
 		onlishopConstraint, err := extension.GetOnlishopProjectConstraint(projectRoot)
 		if err != nil {
@@ -53,16 +57,24 @@ var projectStorefrontBuildCmd = &cobra.Command{
 		}
 
 		assetCfg := extension.AssetBuildConfig{
-			DisableAdminBuild: true,
-			OnlishopRoot:      projectRoot,
-			OnlishopVersion:   onlishopConstraint,
-			NPMForceInstall:   forceInstall,
+			DisableAdminBuild:      true,
+			OnlishopRoot:           projectRoot,
+			OnlishopVersion:        onlishopConstraint,
+			NPMForceInstall:        forceInstall,
+			Jobs:                   jobs,
+			ForceStorefrontESBuild: forceESBuild,
 		}
 
 		if err := extension.BuildAssetsForExtensions(cmd.Context(), sources, assetCfg); err != nil {
 			return err
 		}
 
+		if themeOutput != "" {
+			cfgs := extension.BuildAssetConfigFromExtensions(cmd.Context(), sources, assetCfg)
+
+			return extension.CompileStorefrontTheme(cmd.Context(), projectRoot, cfgs, themeName, themeOutput)
+		}
+
 		skipThemeCompile, _ := cmd.PersistentFlags().GetBool("skip-theme-compile")
 		if skipThemeCompile {
 			return nil
@@ -79,4 +91,8 @@ func init() {
 	projectStorefrontBuildCmd.PersistentFlags().String("only-extensions", "", "Only watch the given extensions (comma separated)")
 	projectStorefrontBuildCmd.PersistentFlags().String("skip-extensions", "", "Skips the given extensions (comma separated)")
 	projectStorefrontBuildCmd.PersistentFlags().Bool("only-custom-static-extensions", false, "Only build extensions from custom/static-plugins directory")
+	projectStorefrontBuildCmd.PersistentFlags().Bool("esbuild", false, "Build the storefront assets of all extensions with ESBuild, so no Node installation is required")
+	projectStorefrontBuildCmd.PersistentFlags().String("theme-output", "", "Compile the Storefront theme natively into this directory instead of running theme:compile")
+	projectStorefrontBuildCmd.PersistentFlags().String("theme", extension.StorefrontThemeName, "Name of the theme to compile with --theme-output")
+	projectStorefrontBuildCmd.PersistentFlags().Int("jobs", 0, "Number of extensions to build concurrently with ESBuild (defaults to the number of CPUs)")
 }
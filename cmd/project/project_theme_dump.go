@@ -0,0 +1,62 @@
+package project
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onlishop/onlishop-cli/extension"
+	"github.com/onlishop/onlishop-cli/logging"
+	"github.com/onlishop/onlishop-cli/shop"
+)
+
+var projectThemeDumpCmd = &cobra.Command{
+	Use:   "theme-dump [path]",
+	Short: "Dumps the theme files and variables of a theme without PHP",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projectRoot string
+		var err error
+
+		if len(args) == 1 {
+			projectRoot, err = filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+		} else if projectRoot, err = findClosestOnlishopProject(); err != nil {
+			return err
+		}
+
+		shopCfg, err := shop.ReadConfig(projectConfigPath, true)
+		if err != nil {
+			return err
+		}
+
+		themeName, _ := cmd.PersistentFlags().GetString("theme")
+
+		sources := extension.FindAssetSourcesOfProject(cmd.Context(), projectRoot, shopCfg)
+		cfgs := extension.BuildAssetConfigFromExtensions(cmd.Context(), sources, extension.AssetBuildConfig{})
+
+		resolver, err := extension.NewThemeResolver(projectRoot, cfgs)
+		if err != nil {
+			return err
+		}
+
+		theme, err := resolver.Resolve(themeName)
+		if err != nil {
+			return err
+		}
+
+		if err := resolver.DumpTheme(projectRoot, theme); err != nil {
+			return err
+		}
+
+		logging.FromContext(cmd.Context()).Infof("Dumped theme %s with %d style and %d script files", theme.Name, len(theme.Styles), len(theme.Scripts))
+
+		return nil
+	},
+}
+
+func init() {
+	projectRootCmd.AddCommand(projectThemeDumpCmd)
+	projectThemeDumpCmd.PersistentFlags().String("theme", extension.StorefrontThemeName, "Name of the theme to dump")
+}
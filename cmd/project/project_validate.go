@@ -6,8 +6,8 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
 
+	"github.com/onlishop/onlishop-cli/internal/git"
 	"github.com/onlishop/onlishop-cli/internal/system"
 	"github.com/onlishop/onlishop-cli/internal/validation"
 	"github.com/onlishop/onlishop-cli/internal/verifier"
@@ -69,9 +69,20 @@ var projectValidateCmd = &cobra.Command{
 			return err
 		}
 
-		result := verifier.NewCheck()
+		if changedSince, _ := cmd.Flags().GetString("changed-since"); changedSince != "" {
+			if generateBaseline, _ := cmd.Flags().GetBool("generate-baseline"); generateBaseline {
+				return fmt.Errorf("--generate-baseline cannot be used with --changed-since, the baseline would only contain the results of the changed files")
+			}
 
-		var gr errgroup.Group
+			toolCfg.ChangedFiles, err = git.GetChangedFiles(cmd.Context(), projectPath, changedSince)
+			if err != nil {
+				return err
+			}
+
+			logging.FromContext(cmd.Context()).Infof("Checking %d files changed since %s", len(toolCfg.ChangedFiles), changedSince)
+		}
+
+		result := verifier.NewCheck()
 
 		tools := verifier.GetTools()
 
@@ -85,27 +96,41 @@ var projectValidateCmd = &cobra.Command{
 			return err
 		}
 
-		for _, tool := range tools {
-			tool := tool
-			gr.Go(func() error {
-				return tool.Check(cmd.Context(), result, *toolCfg)
-			})
+		if err := tools.Check(cmd.Context(), result, *toolCfg); err != nil {
+			return err
 		}
 
-		if err := gr.Wait(); err != nil {
-			return err
+		result.RemoveByIdentifier(toolCfg.ValidationIgnores)
+
+		if toolCfg.OnlyChangedFiles() {
+			result.RemoveUnchanged(*toolCfg)
 		}
 
-		filtered := result.RemoveByIdentifier(toolCfg.ValidationIgnores)
+		baselineFile, _ := cmd.Flags().GetString("baseline")
+		generateBaseline, _ := cmd.Flags().GetBool("generate-baseline")
+		baselineFile = verifier.ResolveBaselineFile(projectPath, baselineFile, generateBaseline)
+
+		if generateBaseline {
+			return verifier.GenerateBaseline(cmd.Context(), result, baselineFile)
+		}
+
+		if baselineFile != "" {
+			if err := verifier.ApplyBaseline(cmd.Context(), result, baselineFile, *toolCfg); err != nil {
+				return err
+			}
+		}
 
-		return validation.DoCheckReport(filtered, reportingFormat)
+		return validation.DoCheckReport(result, reportingFormat)
 	},
 }
 
 func init() {
 	projectRootCmd.AddCommand(projectValidateCmd)
-	projectValidateCmd.PersistentFlags().String("reporter", "", "Reporting format (summary, json, github, gitlab, junit, markdown)")
+	projectValidateCmd.PersistentFlags().String("reporter", "", "Reporting format (summary, json, github, gitlab, junit, markdown, sarif)")
 	projectValidateCmd.PersistentFlags().String("only", "", "Run only specific tools by name (comma-separated, e.g. phpstan,eslint)")
 	projectValidateCmd.PersistentFlags().String("exclude", "", "Exclude specific tools by name (comma-separated, e.g. phpstan,eslint)")
+	projectValidateCmd.PersistentFlags().String("changed-since", "", "Only check files changed since the given git ref")
+	projectValidateCmd.PersistentFlags().String("baseline", "", "Only fail on results which are not part of this baseline file, defaults to .onlishop-validation-baseline.json in the project root when it exists")
+	projectValidateCmd.PersistentFlags().Bool("generate-baseline", false, "Write all current results into the baseline file instead of reporting them, defaults to .onlishop-validation-baseline.json in the project root")
 	projectValidateCmd.PersistentFlags().Bool("no-copy", false, "Do not copy project files to temporary directory")
 }
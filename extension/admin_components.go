@@ -0,0 +1,98 @@
+package extension
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	adminComponentRegExp         = regexp.MustCompile(`Component\.(override|extend)\(\s*['"]([\w-]+)['"]\s*(?:,\s*['"]([\w-]+)['"])?`)
+	adminTemplateImportRegExp    = regexp.MustCompile(`import\s+\w+\s+from\s+['"](\.[^'"]+\.html\.twig)['"]`)
+	adminComponentScriptSuffixes = []string{".js", ".ts"}
+)
+
+// AdminComponentOverride is an administration component overriding or extending a component of the Onlishop administration.
+type AdminComponentOverride struct {
+	// Kind is either override or extend
+	Kind string
+	// Component is the name of the overridden or extended Onlishop component
+	Component string
+	Script    string
+	// Template is the imported twig template, empty when the override only changes the logic
+	Template string
+}
+
+// FindAdminComponentOverrides returns all Component.override and Component.extend calls in the administration folders.
+func FindAdminComponentOverrides(adminDirectories []string) ([]AdminComponentOverride, error) {
+	overrides := make([]AdminComponentOverride, 0)
+
+	for _, adminDirectory := range adminDirectories {
+		err := filepath.WalkDir(adminDirectory, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if d.Name() == "node_modules" || d.Name() == "dist" {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !hasAnySuffix(path, adminComponentScriptSuffixes) {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			var template string
+			if match := adminTemplateImportRegExp.FindStringSubmatch(string(content)); match != nil {
+				template = filepath.Join(filepath.Dir(path), match[1])
+			}
+
+			for _, match := range adminComponentRegExp.FindAllStringSubmatch(string(content), -1) {
+				override := AdminComponentOverride{Kind: match[1], Component: match[2], Script: path, Template: template}
+
+				// Component.extend('new-name', 'extended-name', ...)
+				if match[1] == "extend" {
+					if match[3] == "" {
+						continue
+					}
+
+					override.Component = match[3]
+				}
+
+				overrides = append(overrides, override)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Script < overrides[j].Script
+	})
+
+	return overrides, nil
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
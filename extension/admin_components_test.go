@@ -0,0 +1,56 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAdminComponentOverrides(t *testing.T) {
+	adminDir := t.TempDir()
+
+	files := map[string]string{
+		"src/extension/sw-product-detail/index.js": `import template from './sw-product-detail.html.twig';
+
+Onlishop.Component.override('sw-product-detail', {
+    template,
+});`,
+		"src/component/acme-price-field/index.ts": `import template from './acme-price-field.html.twig';
+
+Component.extend('acme-price-field', 'sw-price-field', { template });`,
+		"src/component/acme-new/index.js":      `Component.register('acme-new', {});`,
+		"src/extension/sw-order-list/index.js": `Component.override("sw-order-list", { methods: {} });`,
+		"node_modules/lib/index.js":            `Component.override('sw-ignored', {});`,
+	}
+
+	for file, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(adminDir, file)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(adminDir, file), []byte(content), 0o644))
+	}
+
+	overrides, err := FindAdminComponentOverrides([]string{adminDir})
+	require.NoError(t, err)
+
+	assert.Equal(t, []AdminComponentOverride{
+		{
+			Kind:      "extend",
+			Component: "sw-price-field",
+			Script:    filepath.Join(adminDir, "src/component/acme-price-field/index.ts"),
+			Template:  filepath.Join(adminDir, "src/component/acme-price-field/acme-price-field.html.twig"),
+		},
+		{
+			Kind:      "override",
+			Component: "sw-order-list",
+			Script:    filepath.Join(adminDir, "src/extension/sw-order-list/index.js"),
+		},
+		{
+			Kind:      "override",
+			Component: "sw-product-detail",
+			Script:    filepath.Join(adminDir, "src/extension/sw-product-detail/index.js"),
+			Template:  filepath.Join(adminDir, "src/extension/sw-product-detail/sw-product-detail.html.twig"),
+		},
+	}, overrides)
+}
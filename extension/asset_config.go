@@ -44,6 +44,8 @@ type AssetBuildConfig struct {
 	ForceExtensionBuild          []string
 	ForceAdminBuild              bool
 	KeepNodeModules              []string
+	Jobs                         int
+	ForceStorefrontESBuild       bool
 }
 
 type ExtensionAssetConfig map[string]*ExtensionAssetConfigEntry
@@ -100,6 +102,18 @@ func (c ExtensionAssetConfig) FilterByAdmin() ExtensionAssetConfig {
 	return filtered
 }
 
+func (c ExtensionAssetConfig) FilterByStorefront() ExtensionAssetConfig {
+	filtered := make(ExtensionAssetConfig)
+
+	for name, entry := range c {
+		if entry.Storefront.EntryFilePath != nil {
+			filtered[name] = entry
+		}
+	}
+
+	return filtered
+}
+
 func (c ExtensionAssetConfig) FilterByAdminAndEsBuild(esbuildEnabled bool) ExtensionAssetConfig {
 	filtered := make(ExtensionAssetConfig)
 
@@ -433,7 +447,7 @@ func BuildAssetConfigFromExtensions(ctx context.Context, sources []asset.Source,
 
 		sourceConfig := createConfigFromPath(source.Name, absPath)
 		sourceConfig.EnableESBuildForAdmin = source.AdminEsbuildCompatible
-		sourceConfig.EnableESBuildForStorefront = source.StorefrontEsbuildCompatible
+		sourceConfig.EnableESBuildForStorefront = source.StorefrontEsbuildCompatible || assetCfg.ForceStorefrontESBuild
 		sourceConfig.DisableSass = source.DisableSass
 		sourceConfig.NpmStrict = source.NpmStrict
 
@@ -0,0 +1,156 @@
+package extension
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/internal/esbuild"
+	"github.com/onlishop/onlishop-cli/internal/table"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+const (
+	esbuildJobAdministration = "Administration"
+	esbuildJobStorefront     = "Storefront"
+)
+
+type esbuildAssetJob struct {
+	name           string
+	kind           string
+	options        esbuild.AssetCompileOptions
+	dumpViteConfig bool
+}
+
+type esbuildAssetResult struct {
+	job      esbuildAssetJob
+	output   string
+	duration time.Duration
+	jsSize   int64
+	err      error
+}
+
+// compileExtensionsWithESBuild compiles the given jobs using a worker pool.
+// The esbuild output of each extension is buffered and printed after all jobs are done, so the output of concurrent builds does not interleave.
+func compileExtensionsWithESBuild(ctx context.Context, jobs []esbuildAssetJob, concurrency int) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].name < jobs[j].name
+	})
+
+	logging.FromContext(ctx).Infof("Building %d extensions using ESBuild with %d workers", len(jobs), concurrency)
+
+	results := make([]esbuildAssetResult, len(jobs))
+	jobChan := make(chan int, len(jobs))
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobChan {
+				results[index] = processESBuildJob(ctx, jobs[index])
+			}
+		}()
+	}
+
+	for i := range jobs {
+		jobChan <- i
+	}
+	close(jobChan)
+
+	wg.Wait()
+
+	var errs []error
+
+	for _, result := range results {
+		if result.output != "" {
+			logging.FromContext(ctx).Infof("ESBuild output of %s (%s):\n%s", result.job.name, result.job.kind, strings.TrimRight(result.output, "\n"))
+		}
+
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("building %s assets of %s: %w", strings.ToLower(result.job.kind), result.job.name, result.err))
+		}
+	}
+
+	if err := renderESBuildSummary(results); err != nil {
+		logging.FromContext(ctx).Errorf("Cannot render build summary: %s", err.Error())
+	}
+
+	return errors.Join(errs...)
+}
+
+func processESBuildJob(ctx context.Context, job esbuildAssetJob) esbuildAssetResult {
+	var output bytes.Buffer
+
+	job.options.LogOutput = &output
+
+	start := time.Now()
+	compileResult, err := esbuild.CompileExtensionAsset(ctx, job.options)
+
+	if err == nil && job.dumpViteConfig {
+		err = esbuild.DumpViteConfig(job.options)
+	}
+
+	result := esbuildAssetResult{
+		job:      job,
+		duration: time.Since(start),
+		err:      err,
+	}
+
+	if compileResult != nil {
+		if stat, statErr := os.Stat(compileResult.JsFile); statErr == nil {
+			result.jsSize = stat.Size()
+		}
+	}
+
+	result.output = output.String()
+
+	return result
+}
+
+func renderESBuildSummary(results []esbuildAssetResult) error {
+	rows := make([][]string, 0, len(results))
+
+	for _, result := range results {
+		status := "OK"
+		if result.err != nil {
+			status = "FAILED"
+		}
+
+		size := "-"
+		if result.jsSize >= 1024 {
+			size = fmt.Sprintf("%.1f KB", float64(result.jsSize)/1024)
+		} else if result.jsSize > 0 {
+			size = fmt.Sprintf("%d B", result.jsSize)
+		}
+
+		rows = append(rows, []string{
+			result.job.name,
+			result.job.kind,
+			status,
+			result.duration.Round(time.Millisecond).String(),
+			size,
+		})
+	}
+
+	return table.RenderTable(os.Stdout, []string{"Extension", "Type", "Status", "Duration", "JS Size"}, rows)
+}
@@ -0,0 +1,75 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/esbuild"
+)
+
+func createESBuildAdminExtension(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	adminDir := filepath.Join(dir, "Resources", "app", "administration", "src")
+
+	assert.NoError(t, os.MkdirAll(adminDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(adminDir, "main.js"), []byte(content), os.ModePerm))
+
+	return dir
+}
+
+func newESBuildAdminJob(name, dir string) esbuildAssetJob {
+	options := esbuild.NewAssetCompileOptionsAdmin(name, dir)
+	options.DisableSass = true
+
+	return esbuildAssetJob{
+		name:    name,
+		kind:    esbuildJobAdministration,
+		options: options,
+	}
+}
+
+func TestCompileExtensionsWithESBuildConcurrently(t *testing.T) {
+	first := createESBuildAdminExtension(t, "console.log('first')")
+	second := createESBuildAdminExtension(t, "console.log('second')")
+
+	jobs := []esbuildAssetJob{
+		newESBuildAdminJob("SecondPlugin", second),
+		newESBuildAdminJob("FirstPlugin", first),
+	}
+
+	assert.NoError(t, compileExtensionsWithESBuild(getTestContext(), jobs, 2))
+
+	assert.FileExists(t, filepath.Join(first, "Resources", "public", "administration", "js", "first-plugin.js"))
+	assert.FileExists(t, filepath.Join(second, "Resources", "public", "administration", "js", "second-plugin.js"))
+}
+
+func TestCompileExtensionsWithESBuildReportsFailingExtension(t *testing.T) {
+	valid := createESBuildAdminExtension(t, "console.log('valid')")
+	broken := createESBuildAdminExtension(t, "console.log(")
+
+	jobs := []esbuildAssetJob{
+		newESBuildAdminJob("ValidPlugin", valid),
+		newESBuildAdminJob("BrokenPlugin", broken),
+	}
+
+	err := compileExtensionsWithESBuild(getTestContext(), jobs, 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "BrokenPlugin")
+	assert.NotContains(t, err.Error(), "ValidPlugin")
+	assert.FileExists(t, filepath.Join(valid, "Resources", "public", "administration", "js", "valid-plugin.js"))
+}
+
+func TestProcessESBuildJobCapturesOutput(t *testing.T) {
+	broken := createESBuildAdminExtension(t, "console.log(")
+
+	result := processESBuildJob(getTestContext(), newESBuildAdminJob("BrokenPlugin", broken))
+
+	assert.Error(t, result.err)
+	assert.Contains(t, result.output, "main.js")
+}
@@ -52,14 +52,20 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 		defer deletePaths(ctx, onlishopRoot)
 	}
 
-	nodeInstallSection := ci.Default.Section(ctx, "Installing node_modules for extensions")
+	paths := make([]string, 0)
 
-	paths, err := InstallNodeModulesOfConfigs(ctx, cfgs, assetConfig.NPMForceInstall)
-	if err != nil {
-		return err
-	}
+	if _, err := exec.LookPath("npm"); err != nil && !requiresOnlishopSources {
+		logging.FromContext(ctx).Warnf("npm is not installed, skipping installation of node_modules for extensions")
+	} else {
+		nodeInstallSection := ci.Default.Section(ctx, "Installing node_modules for extensions")
+
+		paths, err = InstallNodeModulesOfConfigs(ctx, cfgs, assetConfig.NPMForceInstall)
+		if err != nil {
+			return err
+		}
 
-	nodeInstallSection.End(ctx)
+		nodeInstallSection.End(ctx)
+	}
 
 	if onlishopRoot != "" && len(assetConfig.KeepNodeModules) > 0 {
 		paths = slices.DeleteFunc(paths, func(path string) bool {
@@ -78,19 +84,21 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 		administrationSection := ci.Default.Section(ctx, "Building administration assets")
 
 		// Build all extensions compatible with esbuild first
+		adminJobs := make([]esbuildAssetJob, 0)
 		for name, entry := range cfgs.FilterByAdminAndEsBuild(true) {
 			options := esbuild.NewAssetCompileOptionsAdmin(name, entry.BasePath)
 			options.DisableSass = entry.DisableSass
 
-			if _, err := esbuild.CompileExtensionAsset(ctx, options); err != nil {
-				return err
-			}
-
-			if err := esbuild.DumpViteConfig(options); err != nil {
-				return err
-			}
+			adminJobs = append(adminJobs, esbuildAssetJob{
+				name:           name,
+				kind:           esbuildJobAdministration,
+				options:        options,
+				dumpViteConfig: true,
+			})
+		}
 
-			logging.FromContext(ctx).Infof("Building administration assets for %s using ESBuild", name)
+		if err := compileExtensionsWithESBuild(ctx, adminJobs, assetConfig.Jobs); err != nil {
+			return err
 		}
 
 		nonCompatibleExtensions := cfgs.FilterByAdminAndEsBuild(false)
@@ -187,19 +195,29 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 	if !assetConfig.DisableStorefrontBuild && cfgs.RequiresStorefrontBuild() {
 		storefrontSection := ci.Default.Section(ctx, "Building storefront assets")
 		// Build all extensions compatible with esbuild first
-		for name, entry := range cfgs.FilterByStorefrontAndEsBuild(true) {
-			isNewLayout := false
+		isNewLayout := false
 
-			if minVersion == DevVersionNumber || version.Must(version.NewVersion(minVersion)).GreaterThanOrEqual(version.Must(version.NewVersion("6.6.0.0"))) {
-				isNewLayout = true
-			}
+		if minVersion == DevVersionNumber || version.Must(version.NewVersion(minVersion)).GreaterThanOrEqual(version.Must(version.NewVersion("6.6.0.0"))) {
+			isNewLayout = true
+		}
 
+		storefrontJobs := make([]esbuildAssetJob, 0)
+		for name, entry := range cfgs.FilterByStorefrontAndEsBuild(true) {
 			options := esbuild.NewAssetCompileOptionsStorefront(name, entry.BasePath, isNewLayout)
 
-			if _, err := esbuild.CompileExtensionAsset(ctx, options); err != nil {
-				return err
+			if onlishopRoot != "" {
+				ApplyStorefrontResolution(&options, onlishopRoot)
 			}
-			logging.FromContext(ctx).Infof("Building storefront assets for %s using ESBuild", name)
+
+			storefrontJobs = append(storefrontJobs, esbuildAssetJob{
+				name:    name,
+				kind:    esbuildJobStorefront,
+				options: options,
+			})
+		}
+
+		if err := compileExtensionsWithESBuild(ctx, storefrontJobs, assetConfig.Jobs); err != nil {
+			return err
 		}
 
 		nonCompatibleExtensions := cfgs.FilterByStorefrontAndEsBuild(false)
@@ -320,6 +338,21 @@ func BuildAssetsForExtensions(ctx context.Context, sources []asset.Source, asset
 	return nil
 }
 
+// ApplyStorefrontResolution lets esbuild resolve imports like src/plugin-system/plugin.class the same way the Storefront webpack config does.
+func ApplyStorefrontResolution(options *esbuild.AssetCompileOptions, onlishopRoot string) {
+	storefrontRoot := PlatformPath(onlishopRoot, "Storefront", "Resources/app/storefront")
+
+	if _, err := os.Stat(path.Join(storefrontRoot, "src")); err == nil {
+		options.Alias = map[string]string{"src": path.Join(storefrontRoot, "src")}
+	}
+
+	for _, dir := range []string{"node_modules", "vendor"} {
+		if _, err := os.Stat(path.Join(storefrontRoot, dir)); err == nil {
+			options.NodePaths = append(options.NodePaths, path.Join(storefrontRoot, dir))
+		}
+	}
+}
+
 func prepareOnlishopForAsset(onlishopRoot string, cfgs ExtensionAssetConfig) error {
 	varFolder := fmt.Sprintf("%s/var", onlishopRoot)
 	if _, err := os.Stat(varFolder); os.IsNotExist(err) {
@@ -0,0 +1,106 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ChecksumVerification is the result of comparing an extension folder against its checksum.json.
+type ChecksumVerification struct {
+	ExtensionVersion string
+	Modified         []string
+	Added            []string
+	Missing          []string
+}
+
+func (v ChecksumVerification) Valid() bool {
+	return len(v.Modified) == 0 && len(v.Added) == 0 && len(v.Missing) == 0
+}
+
+// ReadChecksumJSON reads the checksum.json of an extracted extension.
+func ReadChecksumJSON(baseFolder string) (*ChecksumJSON, error) {
+	content, err := os.ReadFile(filepath.Join(baseFolder, "checksum.json"))
+	if err != nil {
+		return nil, fmt.Errorf("read checksum file: %w", err)
+	}
+
+	var checksum ChecksumJSON
+	if err := json.Unmarshal(content, &checksum); err != nil {
+		return nil, fmt.Errorf("decode checksum file: %w", err)
+	}
+
+	if checksum.Algorithm != "xxh128" {
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", checksum.Algorithm)
+	}
+
+	return &checksum, nil
+}
+
+// VerifyChecksumJSON recalculates the checksums of the extension folder and compares them with the checksum.json.
+func VerifyChecksumJSON(baseFolder string, ext Extension) (*ChecksumVerification, error) {
+	expected, err := ReadChecksumJSON(baseFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var ignores []string
+	if cfg := ext.GetExtensionConfig(); cfg != nil {
+		ignores = cfg.Build.Zip.Checksum.Ignore
+	}
+
+	actual, err := collectChecksums(baseFolder, ignores)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChecksumVerification{
+		ExtensionVersion: expected.ExtensionVersion,
+		Modified:         make([]string, 0),
+		Added:            make([]string, 0),
+		Missing:          make([]string, 0),
+	}
+
+	for file, hash := range actual {
+		expectedHash, ok := expected.Hashes[file]
+
+		if !ok {
+			result.Added = append(result.Added, file)
+		} else if expectedHash != hash {
+			result.Modified = append(result.Modified, file)
+		}
+	}
+
+	for file := range expected.Hashes {
+		if _, ok := actual[file]; !ok {
+			result.Missing = append(result.Missing, file)
+		}
+	}
+
+	sort.Strings(result.Modified)
+	sort.Strings(result.Added)
+	sort.Strings(result.Missing)
+
+	return result, nil
+}
+
+// Rows returns one table row per changed file, containing the change and the file.
+func (v ChecksumVerification) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Modified)+len(v.Added)+len(v.Missing))
+
+	for _, file := range v.Modified {
+		rows = append(rows, []string{"modified", file})
+	}
+
+	for _, file := range v.Added {
+		rows = append(rows, []string{"added", file})
+	}
+
+	for _, file := range v.Missing {
+		rows = append(rows, []string{"missing", file})
+	}
+
+	return rows
+}
@@ -0,0 +1,59 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shyim/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyChecksumJSON(t *testing.T) {
+	extensionDir := t.TempDir()
+
+	files := map[string]string{
+		"composer.json":               `{"name": "test/test-ext", "version": "1.0.0"}`,
+		"src/Resources/config.js":     "console.log('test');",
+		"src/Resources/removed.txt":   "removed",
+		"src/Resources/generated.txt": "generated",
+	}
+
+	for file, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(extensionDir, file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(extensionDir, file), []byte(content), 0644))
+	}
+
+	ext := &mockExtension{
+		name:       "TestExt",
+		extVersion: version.Must(version.NewVersion("1.0.0")),
+		config:     &Config{},
+	}
+	ext.config.Build.Zip.Checksum.Ignore = []string{"src/Resources/generated.txt"}
+
+	require.NoError(t, GenerateChecksumJSON(t.Context(), extensionDir, ext))
+
+	result, err := VerifyChecksumJSON(extensionDir, ext)
+	require.NoError(t, err)
+	assert.True(t, result.Valid())
+	assert.Equal(t, "1.0.0", result.ExtensionVersion)
+
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "src/Resources/config.js"), []byte("console.log('hotfix');"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "src/Resources/added.txt"), []byte("added"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "src/Resources/generated.txt"), []byte("regenerated"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(extensionDir, "src/Resources/removed.txt")))
+
+	result, err = VerifyChecksumJSON(extensionDir, ext)
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+	assert.Equal(t, []string{"src/Resources/config.js"}, result.Modified)
+	assert.Equal(t, []string{"src/Resources/added.txt"}, result.Added)
+	assert.Equal(t, []string{"src/Resources/removed.txt"}, result.Missing)
+}
+
+func TestVerifyChecksumJSONWithoutChecksumFile(t *testing.T) {
+	_, err := VerifyChecksumJSON(t.TempDir(), &mockExtension{config: &Config{}})
+
+	assert.ErrorContains(t, err, "read checksum file")
+}
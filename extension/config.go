@@ -31,6 +31,10 @@ type ConfigBuildZip struct {
 	Pack ConfigBuildZipPack `yaml:"pack,omitempty"`
 
 	Checksum ConfigBuildZipChecksum `yaml:"checksum,omitempty"`
+	// Configuration for signing
+	Sign ConfigBuildZipSign `yaml:"sign,omitempty"`
+	// Configuration for the software bill of materials
+	Sbom ConfigBuildZipSbom `yaml:"sbom,omitempty"`
 }
 
 // Configuration for checksum calculation.
@@ -39,6 +43,22 @@ type ConfigBuildZipChecksum struct {
 	Ignore []string `yaml:"ignore,omitempty"`
 }
 
+// Configuration for signing the zip and the checksum.json with an ed25519 key, the signatures can be verified with minisign.
+type ConfigBuildZipSign struct {
+	// When enabled, the zip and the checksum.json are signed
+	Enabled bool `yaml:"enabled"`
+	// Path to the secret key relative to the extension, it has to be outside of the extension folder. The ONLISHOP_CLI_SIGNING_KEY environment variable takes precedence
+	SecretKey string `yaml:"secret_key,omitempty"`
+}
+
+// Configuration for the software bill of materials of the bundled composer and npm dependencies.
+type ConfigBuildZipSbom struct {
+	// When enabled, a SBOM is added to the zip
+	Enabled bool `yaml:"enabled"`
+	// Format of the SBOM, defaults to cyclonedx
+	Format string `yaml:"format,omitempty" jsonschema:"enum=cyclonedx,enum=spdx"`
+}
+
 type ConfigBuildZipComposer struct {
 	// When enabled, a vendor folder will be created in the zip build
 	Enabled bool `yaml:"enabled"`
@@ -77,6 +97,8 @@ type ConfigBuildZipPack struct {
 	Excludes ConfigBuildZipPackExcludes `yaml:"excludes,omitempty"`
 	// Commands to run before the pack
 	BeforeHooks []string `yaml:"before_hooks,omitempty"`
+	// When enabled, the zip is byte identical for the same commit (sorted entries, commit date as modification time, normalized permissions)
+	Reproducible bool `yaml:"reproducible,omitempty"`
 }
 
 type ConfigExtraBundle struct {
@@ -166,6 +188,16 @@ type ConfigValidation struct {
 	// Ignore items from the validation.
 	Ignore          ConfigValidationList `yaml:"ignore,omitempty"`
 	StoreCompliance bool                 `yaml:"store_compliance,omitempty"`
+	// License check of the bundled dependencies.
+	License ConfigValidationLicense `yaml:"license,omitempty"`
+	// Configure the twig linter rules.
+	Rules []validation.RuleConfig `yaml:"rules,omitempty"`
+}
+
+// ConfigValidationLicense configures the license check of the bundled composer and npm dependencies.
+type ConfigValidationLicense struct {
+	// SPDX identifiers of licenses which are not allowed in bundled dependencies, a trailing * matches a prefix like GPL-*
+	Deny []string `yaml:"deny,omitempty"`
 }
 
 type ConfigValidationList []validation.ToolConfigIgnore
@@ -5,8 +5,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func gitTagOrBranchOfFolder(ctx context.Context, source string) (string, error) {
@@ -63,3 +66,39 @@ func GitCopyFolder(ctx context.Context, source, target, commitHash string) (stri
 
 	return commitHash, err
 }
+
+// GitCommitTime returns the committer date of the given commit, tag or branch.
+func GitCommitTime(ctx context.Context, source, commitHash string) (time.Time, error) {
+	if commitHash == "" {
+		commitHash = "HEAD"
+	}
+
+	logCmd := exec.CommandContext(ctx, "git", "-C", source, "log", "-1", "--format=%ct", commitHash)
+
+	stdout, err := logCmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GitCommitTime: cannot read commit date of %s: %v", commitHash, err)
+	}
+
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(string(stdout)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GitCommitTime: cannot parse commit date of %s: %v", commitHash, err)
+	}
+
+	return time.Unix(timestamp, 0).UTC(), nil
+}
+
+// SourceDateEpoch returns the time to use for reproducible builds.
+// The SOURCE_DATE_EPOCH environment variable wins over the commit date of the git checkout.
+func SourceDateEpoch(ctx context.Context, source, commitHash string) (time.Time, error) {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		timestamp, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+		}
+
+		return time.Unix(timestamp, 0).UTC(), nil
+	}
+
+	return GitCommitTime(ctx, source, commitHash)
+}
@@ -0,0 +1,84 @@
+package extension
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/internal/sbom"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+// GenerateSBOM writes a bill of materials of the composer.lock and all package-lock.json files of the folder into the folder.
+func GenerateSBOM(ctx context.Context, folder, name, version, format string, timestamp time.Time) (string, error) {
+	document := sbom.Document{
+		Name:      name,
+		Version:   version,
+		Timestamp: timestamp,
+	}
+
+	composerLock := filepath.Join(folder, "composer.lock")
+
+	if _, err := os.Stat(composerLock); err == nil {
+		components, err := sbom.ReadComposerLock(composerLock)
+		if err != nil {
+			return "", err
+		}
+
+		document.Add(components...)
+	}
+
+	packageLocks, err := findPackageLocks(folder)
+	if err != nil {
+		return "", err
+	}
+
+	for _, packageLock := range packageLocks {
+		components, err := sbom.ReadPackageLock(packageLock)
+		if err != nil {
+			return "", err
+		}
+
+		document.Add(components...)
+	}
+
+	file := filepath.Join(folder, sbom.FileName(format))
+
+	if err := sbom.WriteFile(file, format, document); err != nil {
+		return "", err
+	}
+
+	logging.FromContext(ctx).Infof("Generated SBOM %s with %d components", filepath.Base(file), len(document.Components))
+
+	return file, nil
+}
+
+// findPackageLocks returns all package-lock.json files outside of installed dependencies.
+func findPackageLocks(folder string) ([]string, error) {
+	files := make([]string, 0)
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != folder && (d.Name() == "node_modules" || d.Name() == "vendor" || d.Name() == "var" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.Name() == "package-lock.json" {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
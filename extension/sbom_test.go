@@ -0,0 +1,48 @@
+package extension
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onlishop/onlishop-cli/internal/sbom"
+)
+
+func TestGenerateSBOM(t *testing.T) {
+	folder := t.TempDir()
+
+	files := map[string]string{
+		"composer.lock": `{"packages": [{"name": "symfony/polyfill-php83", "version": "v1.31.0", "license": ["MIT"]}]}`,
+		"src/Resources/app/administration/package-lock.json":                     `{"lockfileVersion": 3, "packages": {"node_modules/lodash": {"version": "4.17.21", "license": "MIT"}}}`,
+		"src/Resources/app/administration/node_modules/lodash/package-lock.json": `{"lockfileVersion": 3, "packages": {"node_modules/ignored": {"version": "1.0.0"}}}`,
+		"vendor/acme/package/package-lock.json":                                  `{"lockfileVersion": 3, "packages": {"node_modules/ignored": {"version": "1.0.0"}}}`,
+	}
+
+	for file, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(folder, file)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(folder, file), []byte(content), os.ModePerm))
+	}
+
+	file, err := GenerateSBOM(t.Context(), folder, "SwagExample", "1.0.0", sbom.FormatCycloneDX, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(folder, "sbom.cdx.json"), file)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var bom struct {
+		Components []struct {
+			Purl string `json:"purl"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(content, &bom))
+
+	assert.Len(t, bom.Components, 2)
+	assert.Equal(t, "pkg:composer/symfony/polyfill-php83@1.31.0", bom.Components[0].Purl)
+	assert.Equal(t, "pkg:npm/lodash@4.17.21", bom.Components[1].Purl)
+}
@@ -0,0 +1,181 @@
+package extension
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/internal/signing"
+)
+
+const (
+	// SigningKeyEnv contains the secret key to sign extensions, used instead of the configured key file
+	SigningKeyEnv = "ONLISHOP_CLI_SIGNING_KEY"
+	// SignatureSuffix is appended to the signed file name
+	SignatureSuffix = ".minisig"
+)
+
+// LoadSigningKey returns the secret key to sign the extension, or nil when signing is not enabled.
+func LoadSigningKey(ext Extension) (*signing.SecretKey, error) {
+	cfg := ext.GetExtensionConfig().Build.Zip.Sign
+
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if key := os.Getenv(SigningKeyEnv); key != "" {
+		return signing.ParseSecretKey(key)
+	}
+
+	if cfg.SecretKey == "" {
+		return nil, fmt.Errorf("signing is enabled, but neither %s nor build.zip.sign.secret_key is set", SigningKeyEnv)
+	}
+
+	keyFile := resolveExtensionPath(ext, cfg.SecretKey)
+
+	// A key inside the extension would be packed into the zip, e.g. with --disable-git
+	if isInsideFolder(keyFile, ext.GetPath()) {
+		return nil, fmt.Errorf("the secret key %s is inside the extension folder and would be shipped in the zip, move it outside of the extension", cfg.SecretKey)
+	}
+
+	return signing.ReadSecretKeyFile(keyFile)
+}
+
+// LoadPublicKey reads a public key from the given file or parses it directly, when it is not a file.
+func LoadPublicKey(fileOrKey string) (*signing.PublicKey, error) {
+	if _, err := os.Stat(fileOrKey); err == nil {
+		return signing.ReadPublicKeyFile(fileOrKey)
+	}
+
+	return signing.ParsePublicKey(fileOrKey)
+}
+
+// SignFile writes a minisign signature of the file next to it.
+func SignFile(key *signing.SecretKey, file string) error {
+	return SignFileAt(key, file, time.Now())
+}
+
+// SignFileAt writes a minisign signature of the file next to it, using the given time in the trusted comment.
+func SignFileAt(key *signing.SecretKey, file string, timestamp time.Time) error {
+	signature, err := signing.SignFile(key, file, fmt.Sprintf("timestamp:%d\tfile:%s", timestamp.Unix(), filepath.Base(file)))
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(file+SignatureSuffix, []byte(signature.String()), 0o644); err != nil {
+		return fmt.Errorf("write signature: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyZipSignature verifies the signature of the zip lying next to it and the signed checksum.json inside the zip.
+func VerifyZipSignature(zipFile string, key *signing.PublicKey) error {
+	if _, err := signing.VerifyFile(key, zipFile, zipFile+SignatureSuffix); err != nil {
+		return fmt.Errorf("verify signature of %s: %w", filepath.Base(zipFile), err)
+	}
+
+	content, err := os.ReadFile(zipFile)
+	if err != nil {
+		return err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return fmt.Errorf("open zip: %w", err)
+	}
+
+	checksum, checksumSignature, err := readSignedChecksum(reader)
+	if err != nil {
+		return err
+	}
+
+	if checksumSignature == nil {
+		return nil
+	}
+
+	if checksum == nil {
+		return fmt.Errorf("zip contains a checksum.json signature, but no checksum.json")
+	}
+
+	signature, err := signing.ParseSignature(string(checksumSignature))
+	if err != nil {
+		return err
+	}
+
+	if err := signing.Verify(key, checksum, signature); err != nil {
+		return fmt.Errorf("verify signature of checksum.json: %w", err)
+	}
+
+	return nil
+}
+
+// readSignedChecksum returns the checksum.json and its signature of the extension folder inside the zip.
+func readSignedChecksum(reader *zip.Reader) ([]byte, []byte, error) {
+	var checksum, signature []byte
+
+	for _, file := range reader.File {
+		if strings.Count(strings.TrimSuffix(file.Name, "/"), "/") != 1 {
+			continue
+		}
+
+		name := path.Base(file.Name)
+		if name != "checksum.json" && name != "checksum.json"+SignatureSuffix {
+			continue
+		}
+
+		handle, err := file.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data, err := io.ReadAll(handle)
+		_ = handle.Close()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if name == "checksum.json" {
+			checksum = data
+		} else {
+			signature = data
+		}
+	}
+
+	return checksum, signature, nil
+}
+
+func resolveExtensionPath(ext Extension, file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+
+	return filepath.Join(ext.GetPath(), file)
+}
+
+// isInsideFolder reports whether the file is located in the folder or one of its subfolders
+func isInsideFolder(file, folder string) bool {
+	fileAbs, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	folderAbs, err := filepath.Abs(folder)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(folderAbs, fileAbs)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
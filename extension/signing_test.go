@@ -0,0 +1,110 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onlishop/onlishop-cli/internal/signing"
+)
+
+func createSignedTestZip(t *testing.T, key *signing.SecretKey) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	extDir := filepath.Join(tempDir, "build", "TestExt")
+
+	require.NoError(t, os.MkdirAll(extDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(extDir, "checksum.json"), []byte(`{"algorithm":"xxh128"}`), 0o644))
+	require.NoError(t, SignFile(key, filepath.Join(extDir, "checksum.json")))
+
+	zipFile := filepath.Join(tempDir, "TestExt.zip")
+	require.NoError(t, CreateZip(filepath.Join(tempDir, "build"), zipFile))
+	require.NoError(t, SignFile(key, zipFile))
+
+	return zipFile
+}
+
+func TestVerifyZipSignature(t *testing.T) {
+	public, secret, err := signing.GenerateKey()
+	require.NoError(t, err)
+
+	zipFile := createSignedTestZip(t, secret)
+
+	assert.NoError(t, VerifyZipSignature(zipFile, public))
+
+	otherPublic, _, err := signing.GenerateKey()
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, VerifyZipSignature(zipFile, otherPublic), "verify signature of TestExt.zip")
+}
+
+func TestVerifyZipSignatureOfModifiedZip(t *testing.T) {
+	public, secret, err := signing.GenerateKey()
+	require.NoError(t, err)
+
+	zipFile := createSignedTestZip(t, secret)
+
+	f, err := os.OpenFile(zipFile, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString("tampered")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.ErrorContains(t, VerifyZipSignature(zipFile, public), "does not match the content")
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	ext := &mockExtension{config: &Config{}}
+
+	key, err := LoadSigningKey(ext)
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	_, secret, err := signing.GenerateKey()
+	require.NoError(t, err)
+
+	ext.config.Build.Zip.Sign.Enabled = true
+	t.Setenv(SigningKeyEnv, secret.String())
+
+	key, err = LoadSigningKey(ext)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, key)
+
+	t.Setenv(SigningKeyEnv, "")
+
+	_, err = LoadSigningKey(ext)
+	assert.ErrorContains(t, err, SigningKeyEnv)
+}
+
+func TestLoadSigningKeyInsideExtension(t *testing.T) {
+	dir := t.TempDir()
+	extDir := filepath.Join(dir, "MyExt")
+	require.NoError(t, os.MkdirAll(extDir, os.ModePerm))
+
+	_, secret, err := signing.GenerateKey()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(extDir, "signing.key"), []byte(secret.String()), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "signing.key"), []byte(secret.String()), 0o600))
+
+	ext := &mockExtension{path: extDir, config: &Config{}}
+	ext.config.Build.Zip.Sign.Enabled = true
+	t.Setenv(SigningKeyEnv, "")
+
+	ext.config.Build.Zip.Sign.SecretKey = "signing.key"
+	_, err = LoadSigningKey(ext)
+	assert.ErrorContains(t, err, "inside the extension folder")
+
+	ext.config.Build.Zip.Sign.SecretKey = filepath.Join(extDir, "keys", "..", "signing.key")
+	_, err = LoadSigningKey(ext)
+	assert.ErrorContains(t, err, "inside the extension folder")
+
+	ext.config.Build.Zip.Sign.SecretKey = "../signing.key"
+	key, err := LoadSigningKey(ext)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, key)
+}
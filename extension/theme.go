@@ -59,6 +59,7 @@ func validateTheme(ext Extension, check validation.Check) {
 	}
 }
 
+// themeJSON contains only the fields validated here, the build reads the theme.json into themeManifest
 type themeJSON struct {
 	PreviewMedia string `json:"previewMedia"`
 }
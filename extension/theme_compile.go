@@ -0,0 +1,142 @@
+package extension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cp "github.com/otiai10/copy"
+
+	"github.com/onlishop/onlishop-cli/internal/esbuild"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+// CompileStorefrontTheme compiles the given theme of the project together with the styles of the given extensions
+// using the embedded dart-sass, so neither PHP nor Node is required. The stylesheet is written to css/all.css
+// and the compiled storefront scripts of the theme are copied to js/ inside the output directory.
+func CompileStorefrontTheme(ctx context.Context, projectRoot string, cfgs ExtensionAssetConfig, themeName, outputDir string) error {
+	resolver, err := NewThemeResolver(projectRoot, cfgs)
+	if err != nil {
+		return err
+	}
+
+	theme, err := resolver.Resolve(themeName)
+	if err != nil {
+		return err
+	}
+
+	options := resolver.StyleOptions(theme)
+
+	logging.FromContext(ctx).Infof("Compiling theme %s (%s) with %d style files", theme.Name, strings.Join(theme.Inheritance, " > "), len(options.Styles))
+
+	css, err := esbuild.CompileStorefrontTheme(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(outputDir, "css"), os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "css", "all.css"), []byte(css), os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, script := range theme.Scripts {
+		if _, err := os.Stat(script); os.IsNotExist(err) {
+			logging.FromContext(ctx).Debugf("Skipping missing theme script %s", script)
+			continue
+		}
+
+		target := filepath.Join(outputDir, "js", filepath.Base(filepath.Dir(script)), filepath.Base(script))
+
+		if err := cp.Copy(script, target); err != nil {
+			return fmt.Errorf("cannot copy theme script %s: %w", script, err)
+		}
+	}
+
+	return nil
+}
+
+// themeManifest is the theme.json as read by the theme build and the inheritance resolver
+type themeManifest struct {
+	Name              string              `json:"name"`
+	PreviewMedia      string              `json:"previewMedia"`
+	Views             []string            `json:"views"`
+	Style             []string            `json:"style"`
+	Script            []string            `json:"script"`
+	ConfigInheritance []string            `json:"configInheritance"`
+	Config            themeManifestConfig `json:"config"`
+}
+
+type themeManifestConfig struct {
+	Fields map[string]themeManifestField `json:"fields"`
+}
+
+type themeManifestField struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+	Scss  *bool           `json:"scss"`
+}
+
+func readThemeJSON(file string) (*themeManifest, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read theme.json: %w", err)
+	}
+
+	var theme themeManifest
+	if err := json.Unmarshal(content, &theme); err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %w", file, err)
+	}
+
+	return &theme, nil
+}
+
+// scssVariables converts the theme config fields to SCSS variable values as the Onlishop theme compiler does.
+func (t themeManifest) scssVariables() map[string]string {
+	variables := map[string]string{
+		"sw-asset-public-url": "''",
+		"sw-asset-theme-url":  "''",
+		"sw-asset-asset-url":  "''",
+	}
+
+	for name, field := range t.Config.Fields {
+		if field.Scss != nil && !*field.Scss {
+			continue
+		}
+
+		if value, ok := field.scssValue(); ok {
+			variables[name] = value
+		}
+	}
+
+	return variables
+}
+
+func (f themeManifestField) scssValue() (string, bool) {
+	if len(f.Value) == 0 || string(f.Value) == "null" {
+		return "", false
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(f.Value, &value); err != nil {
+		return "", false
+	}
+
+	switch typed := value.(type) {
+	case string:
+		if f.Type == "color" || f.Type == "fontFamily" {
+			return typed, typed != ""
+		}
+
+		return "'" + strings.ReplaceAll(typed, "'", "\\'") + "'", true
+	case bool, float64:
+		return string(f.Value), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,49 @@
+package extension
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThemeScssVariables(t *testing.T) {
+	var theme themeManifest
+
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"config": {
+			"fields": {
+				"sw-color-brand-primary": {"type": "color", "value": "#0042a0"},
+				"sw-font-family-base": {"type": "fontFamily", "value": "'Inter', sans-serif"},
+				"sw-logo-desktop": {"type": "media", "value": "app/storefront/dist/assets/logo/demostore-logo.png"},
+				"sw-border-radius-default": {"type": "text", "value": "3px", "scss": false},
+				"sw-color-empty": {"type": "color", "value": ""},
+				"sw-logo-share": {"type": "media", "value": null},
+				"sw-show-teaser": {"type": "switch", "value": true}
+			}
+		}
+	}`), &theme))
+
+	variables := theme.scssVariables()
+
+	assert.Equal(t, "#0042a0", variables["sw-color-brand-primary"])
+	assert.Equal(t, "'Inter', sans-serif", variables["sw-font-family-base"])
+	assert.Equal(t, "'app/storefront/dist/assets/logo/demostore-logo.png'", variables["sw-logo-desktop"])
+	assert.Equal(t, "true", variables["sw-show-teaser"])
+	assert.Equal(t, "''", variables["sw-asset-theme-url"])
+	assert.NotContains(t, variables, "sw-border-radius-default")
+	assert.NotContains(t, variables, "sw-color-empty")
+	assert.NotContains(t, variables, "sw-logo-share")
+}
+
+func TestCompileStorefrontThemeRequiresThemeJSON(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectRoot, "vendor", "onlishop", "storefront", "Resources"), os.ModePerm))
+
+	err := CompileStorefrontTheme(getTestContext(), projectRoot, ExtensionAssetConfig{}, StorefrontThemeName, t.TempDir())
+
+	assert.ErrorContains(t, err, "cannot read theme.json")
+}
@@ -0,0 +1,379 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onlishop/onlishop-cli/internal/esbuild"
+)
+
+const (
+	StorefrontThemeName = "Storefront"
+
+	themePlaceholderPlugins     = "@Plugins"
+	themePlaceholderParentTheme = "@ParentTheme"
+)
+
+// ThemeResolver resolves the theme.json files of the Storefront and all extensions including their inheritance.
+type ThemeResolver struct {
+	storefrontResources string
+	themes              map[string]*themeSource
+	plugins             map[string]*ExtensionAssetConfigEntry
+}
+
+type themeSource struct {
+	name      string
+	resources string
+	config    *themeManifest
+}
+
+// ResolvedTheme is a theme with all placeholders of its theme.json expanded to absolute paths.
+type ResolvedTheme struct {
+	Name string
+	// Inheritance contains the theme names from the Storefront down to the theme itself
+	Inheritance []string
+	Views       []string
+	Styles      []string
+	Scripts     []string
+	Variables   map[string]string
+}
+
+func NewThemeResolver(projectRoot string, cfgs ExtensionAssetConfig) (*ThemeResolver, error) {
+	resolver := &ThemeResolver{
+		storefrontResources: PlatformPath(projectRoot, "Storefront", "Resources"),
+		themes:              make(map[string]*themeSource),
+		plugins:             make(map[string]*ExtensionAssetConfigEntry),
+	}
+
+	storefront, err := readThemeJSON(filepath.Join(resolver.storefrontResources, "theme.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.themes[StorefrontThemeName] = &themeSource{name: StorefrontThemeName, resources: resolver.storefrontResources, config: storefront}
+
+	for name, entry := range cfgs {
+		resources := filepath.Join(entry.BasePath, "Resources")
+		themeFile := filepath.Join(resources, "theme.json")
+
+		if _, err := os.Stat(themeFile); os.IsNotExist(err) {
+			resolver.plugins[name] = entry
+			continue
+		}
+
+		config, err := readThemeJSON(themeFile)
+		if err != nil {
+			return nil, err
+		}
+
+		resolver.themes[name] = &themeSource{name: name, resources: resources, config: config}
+	}
+
+	return resolver, nil
+}
+
+// Themes returns the names of all known themes.
+func (r *ThemeResolver) Themes() []string {
+	names := make([]string, 0, len(r.themes))
+	for name := range r.themes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *ThemeResolver) Resolve(name string) (*ResolvedTheme, error) {
+	if _, ok := r.themes[name]; !ok {
+		return nil, fmt.Errorf("theme %s not found", name)
+	}
+
+	chain, err := r.inheritance(name)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &ResolvedTheme{
+		Name:        name,
+		Inheritance: chain,
+		Variables:   map[string]string{},
+	}
+
+	if resolved.Views, err = r.expand(name, themeSectionViews, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	if resolved.Styles, err = r.expand(name, themeSectionStyle, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	if resolved.Scripts, err = r.expand(name, themeSectionScript, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]themeManifestField)
+
+	for _, themeName := range chain {
+		for fieldName, field := range r.themes[themeName].config.Config.Fields {
+			if parent, ok := fields[fieldName]; ok {
+				if field.Type == "" {
+					field.Type = parent.Type
+				}
+
+				if len(field.Value) == 0 {
+					field.Value = parent.Value
+				}
+
+				if field.Scss == nil {
+					field.Scss = parent.Scss
+				}
+			}
+
+			fields[fieldName] = field
+		}
+	}
+
+	resolved.Variables = themeManifest{Config: themeManifestConfig{Fields: fields}}.scssVariables()
+
+	return resolved, nil
+}
+
+// StyleOptions returns the options to compile the theme with the embedded dart-sass.
+func (r *ThemeResolver) StyleOptions(theme *ResolvedTheme) esbuild.StorefrontThemeOptions {
+	return esbuild.StorefrontThemeOptions{
+		Styles:         theme.Styles,
+		Variables:      theme.Variables,
+		VendorPaths:    r.VendorPaths(),
+		ProductionMode: true,
+	}
+}
+
+// VendorPaths returns the directories imports prefixed with ~ are resolved from.
+func (r *ThemeResolver) VendorPaths() []string {
+	storefrontApp := filepath.Join(r.storefrontResources, "app", "storefront")
+
+	return []string{filepath.Join(storefrontApp, "vendor"), filepath.Join(storefrontApp, "node_modules")}
+}
+
+// inheritance returns the parent chain of a theme, starting with the Storefront.
+func (r *ThemeResolver) inheritance(name string) ([]string, error) {
+	chain := []string{name}
+	visited := map[string]bool{name: true}
+
+	for current := name; current != StorefrontThemeName; {
+		parent := r.parentOf(current)
+
+		if _, ok := r.themes[parent]; !ok {
+			return nil, fmt.Errorf("parent theme %s of %s not found", parent, current)
+		}
+
+		if visited[parent] {
+			return nil, fmt.Errorf("theme %s has a circular inheritance over %s", name, parent)
+		}
+
+		visited[parent] = true
+		chain = append([]string{parent}, chain...)
+		current = parent
+	}
+
+	return chain, nil
+}
+
+// parentOf returns the direct parent of a theme, which is the last theme referenced in configInheritance.
+func (r *ThemeResolver) parentOf(name string) string {
+	inheritance := r.themes[name].config.ConfigInheritance
+
+	for i := len(inheritance) - 1; i >= 0; i-- {
+		parent := strings.TrimPrefix(inheritance[i], "@")
+
+		if parent != name && parent != strings.TrimPrefix(themePlaceholderParentTheme, "@") {
+			return parent
+		}
+	}
+
+	return StorefrontThemeName
+}
+
+type themeSection int
+
+const (
+	themeSectionViews themeSection = iota
+	themeSectionStyle
+	themeSectionScript
+)
+
+func (s themeSection) entries(source *themeSource) []string {
+	switch s {
+	case themeSectionViews:
+		return source.config.Views
+	case themeSectionScript:
+		return source.config.Script
+	default:
+		return source.config.Style
+	}
+}
+
+// expand replaces the placeholders of one theme.json section with absolute paths.
+func (r *ThemeResolver) expand(name string, section themeSection, visiting map[string]bool) ([]string, error) {
+	if visiting[name] {
+		return nil, fmt.Errorf("theme %s references itself", name)
+	}
+
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	source := r.themes[name]
+	entries := section.entries(source)
+
+	// The views of a theme without an explicit list are the Storefront, all plugins and the theme itself
+	if len(entries) == 0 && section == themeSectionViews {
+		entries = []string{"@" + StorefrontThemeName, themePlaceholderPlugins, "@" + name}
+	}
+
+	result := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(files ...string) {
+		for _, file := range files {
+			if !seen[file] {
+				seen[file] = true
+				result = append(result, file)
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry, "@") {
+			add(filepath.Join(source.resources, entry))
+			continue
+		}
+
+		reference := strings.TrimPrefix(entry, "@")
+
+		switch {
+		case entry == themePlaceholderPlugins:
+			add(r.pluginFiles(section)...)
+		case entry == themePlaceholderParentTheme:
+			if name == StorefrontThemeName {
+				continue
+			}
+
+			files, err := r.expand(r.parentOf(name), section, visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			add(files...)
+		case reference == name:
+			if section == themeSectionViews {
+				add(filepath.Join(source.resources, "views"))
+			}
+		case r.themes[reference] != nil:
+			files, err := r.expand(reference, section, visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			add(files...)
+		case r.plugins[reference] != nil:
+			add(pluginFilesOf(r.plugins[reference], section)...)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *ThemeResolver) pluginFiles(section themeSection) []string {
+	names := make([]string, 0, len(r.plugins))
+	for name := range r.plugins {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	files := make([]string, 0)
+	for _, name := range names {
+		files = append(files, pluginFilesOf(r.plugins[name], section)...)
+	}
+
+	return files
+}
+
+func pluginFilesOf(entry *ExtensionAssetConfigEntry, section themeSection) []string {
+	files := make([]string, 0)
+
+	switch section {
+	case themeSectionViews:
+		for _, view := range entry.Views {
+			files = append(files, filepath.Join(entry.BasePath, view))
+		}
+	case themeSectionScript:
+		if entry.Storefront.EntryFilePath == nil {
+			return files
+		}
+
+		files = append(files, filepath.Join(entry.GetOutputStorefrontPath(), "js", entry.TechnicalName, entry.TechnicalName+".js"))
+	default:
+		for _, style := range entry.Storefront.StyleFiles {
+			files = append(files, filepath.Join(entry.BasePath, style))
+		}
+	}
+
+	return files
+}
+
+// ThemeFilesJSON mirrors the var/theme-files.json written by theme:dump.
+type ThemeFilesJSON struct {
+	Style       []ThemeFile `json:"style"`
+	Script      []ThemeFile `json:"script"`
+	Views       []string    `json:"views"`
+	Inheritance []string    `json:"inheritance"`
+}
+
+type ThemeFile struct {
+	Filepath       string            `json:"filepath"`
+	ResolveMapping map[string]string `json:"resolveMapping"`
+}
+
+// DumpTheme writes var/theme-files.json and var/theme-variables.scss of the theme into the project, like theme:dump does.
+func (r *ThemeResolver) DumpTheme(projectRoot string, theme *ResolvedTheme) error {
+	resolveMapping := map[string]string{"vendor": r.VendorPaths()[0]}
+
+	files := ThemeFilesJSON{
+		Style:       make([]ThemeFile, 0, len(theme.Styles)),
+		Script:      make([]ThemeFile, 0, len(theme.Scripts)),
+		Views:       theme.Views,
+		Inheritance: theme.Inheritance,
+	}
+
+	for _, style := range theme.Styles {
+		files.Style = append(files.Style, ThemeFile{Filepath: style, ResolveMapping: resolveMapping})
+	}
+
+	for _, script := range theme.Scripts {
+		files.Script = append(files.Script, ThemeFile{Filepath: script, ResolveMapping: map[string]string{}})
+	}
+
+	content, err := json.MarshalIndent(files, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	varDir := filepath.Join(projectRoot, "var")
+	if err := os.MkdirAll(varDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(varDir, "theme-files.json"), content, os.ModePerm); err != nil {
+		return err
+	}
+
+	variables := esbuild.BuildStorefrontThemeSource(esbuild.StorefrontThemeOptions{Variables: theme.Variables})
+
+	return os.WriteFile(filepath.Join(varDir, "theme-variables.scss"), []byte("// ATTENTION! This file is auto generated by the onlishop-cli and should not be edited.\n\n"+variables), os.ModePerm)
+}
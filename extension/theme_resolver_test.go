@@ -0,0 +1,174 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeThemeJSON(t *testing.T, resources, content string) {
+	t.Helper()
+
+	assert.NoError(t, os.MkdirAll(resources, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(resources, "theme.json"), []byte(content), os.ModePerm))
+}
+
+func createThemeProject(t *testing.T) (string, ExtensionAssetConfig) {
+	t.Helper()
+
+	projectRoot := t.TempDir()
+
+	writeThemeJSON(t, filepath.Join(projectRoot, "vendor", "onlishop", "storefront", "Resources"), `{
+		"name": "Storefront",
+		"views": ["@Storefront", "@Plugins"],
+		"style": ["app/storefront/src/scss/base.scss", "@Plugins"],
+		"script": ["app/storefront/dist/storefront/js/storefront/storefront.js", "@Plugins"],
+		"config": {
+			"fields": {
+				"sw-color-brand-primary": {"type": "color", "value": "#0042a0"},
+				"sw-color-brand-secondary": {"type": "color", "value": "#526e7f"}
+			}
+		}
+	}`)
+
+	cfgs := ExtensionAssetConfig{}
+
+	for _, name := range []string{"SwagPlugin", "SwagParent", "ChildTheme"} {
+		basePath := filepath.Join(projectRoot, "custom", "plugins", name, "src") + "/"
+		entryPath := StorefrontEntrypointJS
+
+		cfgs[name] = &ExtensionAssetConfigEntry{
+			BasePath:      basePath,
+			TechnicalName: strings.ToLower(name),
+			Views:         []string{"Resources/views"},
+			Storefront: ExtensionAssetConfigStorefront{
+				EntryFilePath: &entryPath,
+				StyleFiles:    []string{StorefrontBaseCSS},
+			},
+		}
+	}
+
+	writeThemeJSON(t, filepath.Join(cfgs["SwagParent"].BasePath, "Resources"), `{
+		"name": "SwagParent",
+		"views": ["@Storefront", "@Plugins", "@SwagParent"],
+		"style": ["app/storefront/src/scss/overrides.scss", "@Storefront", "app/storefront/src/scss/base.scss"],
+		"script": ["@Storefront", "app/storefront/dist/storefront/js/parent-theme/parent-theme.js"],
+		"config": {
+			"fields": {
+				"sw-color-brand-primary": {"type": "color", "value": "#ff0000"},
+				"parent-headline": {"type": "text", "value": "Parent"}
+			}
+		}
+	}`)
+
+	writeThemeJSON(t, filepath.Join(cfgs["ChildTheme"].BasePath, "Resources"), `{
+		"name": "ChildTheme",
+		"configInheritance": ["@Storefront", "@SwagParent"],
+		"style": ["@ParentTheme", "app/storefront/src/scss/child.scss"],
+		"script": ["@ParentTheme"],
+		"config": {
+			"fields": {
+				"parent-headline": {"value": "Child"},
+				"sw-color-brand-secondary": {"scss": false}
+			}
+		}
+	}`)
+
+	return projectRoot, cfgs
+}
+
+func TestThemeResolverStorefront(t *testing.T) {
+	projectRoot, cfgs := createThemeProject(t)
+
+	resolver, err := NewThemeResolver(projectRoot, cfgs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ChildTheme", "Storefront", "SwagParent"}, resolver.Themes())
+
+	theme, err := resolver.Resolve(StorefrontThemeName)
+	assert.NoError(t, err)
+
+	storefront := filepath.Join(projectRoot, "vendor", "onlishop", "storefront", "Resources")
+	plugin := cfgs["SwagPlugin"].BasePath
+
+	assert.Equal(t, []string{StorefrontThemeName}, theme.Inheritance)
+	assert.Equal(t, []string{filepath.Join(storefront, "views"), filepath.Join(plugin, "Resources/views")}, theme.Views)
+	assert.Equal(t, []string{filepath.Join(storefront, "app/storefront/src/scss/base.scss"), filepath.Join(plugin, StorefrontBaseCSS)}, theme.Styles)
+	assert.Equal(t, []string{
+		filepath.Join(storefront, "app/storefront/dist/storefront/js/storefront/storefront.js"),
+		filepath.Join(plugin, "Resources/app/storefront/dist/storefront/js/swagplugin/swagplugin.js"),
+	}, theme.Scripts)
+	assert.Equal(t, "#0042a0", theme.Variables["sw-color-brand-primary"])
+}
+
+func TestThemeResolverInheritance(t *testing.T) {
+	projectRoot, cfgs := createThemeProject(t)
+
+	resolver, err := NewThemeResolver(projectRoot, cfgs)
+	assert.NoError(t, err)
+
+	theme, err := resolver.Resolve("ChildTheme")
+	assert.NoError(t, err)
+
+	storefront := filepath.Join(projectRoot, "vendor", "onlishop", "storefront", "Resources")
+	plugin := cfgs["SwagPlugin"].BasePath
+	parent := filepath.Join(cfgs["SwagParent"].BasePath, "Resources")
+	child := filepath.Join(cfgs["ChildTheme"].BasePath, "Resources")
+
+	assert.Equal(t, []string{StorefrontThemeName, "SwagParent", "ChildTheme"}, theme.Inheritance)
+	assert.Equal(t, []string{
+		filepath.Join(parent, "app/storefront/src/scss/overrides.scss"),
+		filepath.Join(storefront, "app/storefront/src/scss/base.scss"),
+		filepath.Join(plugin, StorefrontBaseCSS),
+		filepath.Join(parent, "app/storefront/src/scss/base.scss"),
+		filepath.Join(child, "app/storefront/src/scss/child.scss"),
+	}, theme.Styles)
+	assert.Equal(t, []string{
+		filepath.Join(storefront, "views"),
+		filepath.Join(plugin, "Resources/views"),
+		filepath.Join(child, "views"),
+	}, theme.Views)
+	assert.Contains(t, theme.Scripts, filepath.Join(parent, "app/storefront/dist/storefront/js/parent-theme/parent-theme.js"))
+
+	assert.Equal(t, "#ff0000", theme.Variables["sw-color-brand-primary"])
+	assert.Equal(t, "'Child'", theme.Variables["parent-headline"])
+	assert.NotContains(t, theme.Variables, "sw-color-brand-secondary")
+}
+
+func TestThemeResolverErrors(t *testing.T) {
+	projectRoot, cfgs := createThemeProject(t)
+
+	writeThemeJSON(t, filepath.Join(cfgs["SwagParent"].BasePath, "Resources"), `{"configInheritance": ["@ChildTheme"]}`)
+
+	resolver, err := NewThemeResolver(projectRoot, cfgs)
+	assert.NoError(t, err)
+
+	_, err = resolver.Resolve("ChildTheme")
+	assert.ErrorContains(t, err, "circular inheritance")
+
+	_, err = resolver.Resolve("Unknown")
+	assert.ErrorContains(t, err, "theme Unknown not found")
+}
+
+func TestThemeResolverDump(t *testing.T) {
+	projectRoot, cfgs := createThemeProject(t)
+
+	resolver, err := NewThemeResolver(projectRoot, cfgs)
+	assert.NoError(t, err)
+
+	theme, err := resolver.Resolve("ChildTheme")
+	assert.NoError(t, err)
+
+	assert.NoError(t, resolver.DumpTheme(projectRoot, theme))
+
+	files, err := os.ReadFile(filepath.Join(projectRoot, "var", "theme-files.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(files), "child.scss")
+	assert.Contains(t, string(files), "resolveMapping")
+
+	variables, err := os.ReadFile(filepath.Join(projectRoot, "var", "theme-variables.scss"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(variables), "$sw-color-brand-primary: #ff0000;")
+}
@@ -223,6 +223,34 @@ func TestValidateTheme_ValidThemeWithSubdirectory(t *testing.T) {
 	assert.Empty(t, check.Results)
 }
 
+func TestValidateTheme_IgnoresFieldsOfTheBuild(t *testing.T) {
+	for _, content := range []string{
+		`{"previewMedia": "preview.png", "config": []}`,
+		`{"previewMedia": "preview.png", "config": {"fields": []}}`,
+		`{"previewMedia": "preview.png", "views": ["@Storefront", {"name": "@Plugins"}]}`,
+	} {
+		tmpDir := t.TempDir()
+		resourcesDir := filepath.Join(tmpDir, "Resources")
+		assert.NoError(t, os.MkdirAll(resourcesDir, 0755))
+
+		srcResourcesDir := filepath.Join(tmpDir, "src/Resources")
+		assert.NoError(t, os.MkdirAll(srcResourcesDir, 0755))
+		assert.NoError(t, createTestImage(filepath.Join(srcResourcesDir, "preview.png")))
+
+		assert.NoError(t, os.WriteFile(filepath.Join(resourcesDir, "theme.json"), []byte(content), 0644))
+
+		ext := &mockExtension{
+			path:    tmpDir,
+			rootDir: tmpDir,
+		}
+
+		check := &testCheck{}
+		validateTheme(ext, check)
+
+		assert.Empty(t, check.Results, content)
+	}
+}
+
 func TestThemeJSON_UnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,36 @@
+package extension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// TemplateDirs returns the Resources/views directories of all extensions in the project.
+func TemplateDirs(ctx context.Context, projectRoot string) []string {
+	dirs := make([]string, 0)
+
+	for _, ext := range FindExtensionsFromProject(ctx, projectRoot) {
+		for _, resourcesDir := range ext.GetResourcesDirs() {
+			dirs = append(dirs, filepath.Join(resourcesDir, "views"))
+		}
+	}
+
+	return dirs
+}
+
+// ClearTwigCache removes only the compiled twig templates of all kernels in var/cache, so the rest of the cache stays warm.
+func ClearTwigCache(projectRoot string) error {
+	twigDirs, err := filepath.Glob(filepath.Join(projectRoot, "var", "cache", "*", "twig"))
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range twigDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClearTwigCache(t *testing.T) {
+	projectRoot := t.TempDir()
+
+	twigDir := filepath.Join(projectRoot, "var", "cache", "dev_h1a2b3", "twig", "ab")
+	containerFile := filepath.Join(projectRoot, "var", "cache", "dev_h1a2b3", "Container.php")
+
+	assert.NoError(t, os.MkdirAll(twigDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(twigDir, "template.php"), []byte("<?php"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(containerFile, []byte("<?php"), os.ModePerm))
+
+	assert.NoError(t, ClearTwigCache(projectRoot))
+
+	assert.NoDirExists(t, filepath.Join(projectRoot, "var", "cache", "dev_h1a2b3", "twig"))
+	assert.FileExists(t, containerFile)
+}
+
+func TestClearTwigCacheWithoutCache(t *testing.T) {
+	assert.NoError(t, ClearTwigCache(t.TempDir()))
+}
@@ -94,6 +94,7 @@ func RunValidation(ctx context.Context, ext Extension, check validation.Check) {
 	validateStorefrontSnippets(ext, check)
 	validateAssets(ext, check)
 	validateExtensionIcon(ext, check)
+	validateDependencyLicenses(ext, check)
 	// Note: ignores are now applied in the verifier layer
 }
 
@@ -0,0 +1,228 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onlishop/onlishop-cli/internal/packagist"
+	"github.com/onlishop/onlishop-cli/internal/sbom"
+	"github.com/onlishop/onlishop-cli/internal/spdx"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+)
+
+// validateDependencyLicenses checks the licenses of the bundled composer packages and production npm dependencies.
+func validateDependencyLicenses(ext Extension, check validation.Check) {
+	spdxList, err := spdx.NewSpdxLicenses()
+	if err != nil {
+		return
+	}
+
+	var deny []string
+	if cfg := ext.GetExtensionConfig(); cfg != nil {
+		deny = cfg.Validation.License.Deny
+	}
+
+	targets := []string{"proprietary"}
+
+	if license, err := ext.GetLicense(); err == nil {
+		if choices, err := spdxList.Choices(license); err == nil {
+			targets = make([]string, 0)
+			for _, choice := range choices {
+				targets = append(targets, choice...)
+			}
+		}
+	}
+
+	dependencies := bundledDependencies(ext.GetPath(), ext.GetExtensionConfig())
+
+	files := make([]string, 0, len(dependencies))
+	for file := range dependencies {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		for _, component := range dependencies[file] {
+			validateDependencyLicense(spdxList, file, component, targets, deny, check)
+		}
+	}
+}
+
+// bundledDependencies returns the components of the composer.lock and the package-lock.json files by their relative path.
+// Composer packages are only included when the zip build installs them into the extension.
+func bundledDependencies(extensionPath string, cfg *Config) map[string][]sbom.Component {
+	dependencies := make(map[string][]sbom.Component)
+
+	if cfg != nil && cfg.Build.Zip.Composer.Enabled {
+		if components, err := sbom.ReadComposerLock(filepath.Join(extensionPath, "composer.lock")); err == nil {
+			bundled := bundledComposerPackages(extensionPath, cfg)
+			filtered := make([]sbom.Component, 0, len(components))
+
+			for _, component := range components {
+				if bundled[component.Name] {
+					filtered = append(filtered, component)
+				}
+			}
+
+			dependencies["composer.lock"] = filtered
+		}
+	}
+
+	packageLocks, err := findPackageLocks(extensionPath)
+	if err != nil {
+		return dependencies
+	}
+
+	for _, packageLock := range packageLocks {
+		components, err := sbom.ReadPackageLock(packageLock)
+		if err != nil {
+			continue
+		}
+
+		relPath, err := filepath.Rel(extensionPath, packageLock)
+		if err != nil {
+			relPath = packageLock
+		}
+
+		dependencies[filepath.ToSlash(relPath)] = components
+	}
+
+	return dependencies
+}
+
+// bundledComposerPackages returns the packages installed by the zip build. Like in PrepareFolderForZipping these are the
+// requirements of the composer.json without the packages provided by the shop, and their dependencies from the composer.lock.
+func bundledComposerPackages(extensionPath string, cfg *Config) map[string]bool {
+	bundled := make(map[string]bool)
+
+	content, err := os.ReadFile(filepath.Join(extensionPath, "composer.json"))
+	if err != nil {
+		return bundled
+	}
+
+	var composer map[string]interface{}
+	if err := json.Unmarshal(content, &composer); err != nil {
+		return bundled
+	}
+
+	lock, err := packagist.ReadComposerLock(filepath.Join(extensionPath, "composer.lock"))
+	if err != nil {
+		return bundled
+	}
+
+	composer = filterRequires(composer, cfg)
+
+	// Provided and replaced packages are not installed, neither are their dependencies
+	skipped := make(map[string]bool)
+	for _, key := range []string{"provide", "replace"} {
+		if packages, ok := composer[key].(map[string]interface{}); ok {
+			for name := range packages {
+				skipped[name] = true
+			}
+		}
+	}
+
+	queue := make([]string, 0)
+	if require, ok := composer["require"].(map[string]interface{}); ok {
+		for name := range require {
+			queue = append(queue, name)
+		}
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		if bundled[name] || skipped[name] {
+			continue
+		}
+
+		// Platform packages like php or ext-json are not part of the lock
+		pkg := lock.GetPackage(name)
+		if pkg == nil {
+			continue
+		}
+
+		bundled[name] = true
+
+		for dependency := range pkg.Require {
+			queue = append(queue, dependency)
+		}
+	}
+
+	return bundled
+}
+
+func validateDependencyLicense(spdxList *spdx.SpdxLicenses, file string, component sbom.Component, targets, deny []string, check validation.Check) {
+	if len(component.Licenses) == 0 {
+		check.AddResult(validation.CheckResult{
+			Path:       file,
+			Identifier: "license.dependencies",
+			Message:    fmt.Sprintf("The %s package %s has no license", component.Ecosystem, component.Name),
+			Severity:   validation.SeverityWarning,
+		})
+
+		return
+	}
+
+	// Multiple licenses in composer.json are a choice for the licensee
+	choices, err := spdxList.Choices(strings.Join(component.Licenses, " OR "))
+	if err != nil {
+		check.AddResult(validation.CheckResult{
+			Path:       file,
+			Identifier: "license.dependencies",
+			Message:    fmt.Sprintf("The license %s of the %s package %s is not a valid SPDX expression", strings.Join(component.Licenses, ", "), component.Ecosystem, component.Name),
+			Severity:   validation.SeverityWarning,
+		})
+
+		return
+	}
+
+	var problem string
+
+	for _, choice := range choices {
+		problem = licenseChoiceProblem(choice, targets, deny)
+
+		if problem == "" {
+			return
+		}
+	}
+
+	check.AddResult(validation.CheckResult{
+		Path:       file,
+		Identifier: "license.dependencies",
+		Message:    fmt.Sprintf("The %s package %s@%s cannot be bundled: %s", component.Ecosystem, component.Name, component.Version, problem),
+		Severity:   validation.SeverityError,
+	})
+}
+
+// licenseChoiceProblem returns why the licenses of one alternative cannot be used or an empty string.
+func licenseChoiceProblem(choice, targets, deny []string) string {
+	for _, license := range choice {
+		for _, pattern := range deny {
+			if spdx.Matches(license, pattern) {
+				return fmt.Sprintf("the license %s is denied", license)
+			}
+		}
+
+		compatible := false
+
+		for _, target := range targets {
+			if spdx.IsCompatible(license, target) {
+				compatible = true
+				break
+			}
+		}
+
+		if !compatible {
+			return fmt.Sprintf("the license %s is incompatible with the extension license %s", license, strings.Join(targets, " OR "))
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,83 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDependencyLicenses(t *testing.T) {
+	extensionDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "composer.json"), []byte(`{"require": {
+		"php": ">=8.2",
+		"onlishop/core": "~6.6.0",
+		"acme/dual": "^1.0",
+		"acme/copyleft": "^1.0",
+		"acme/excluded": "^1.0"
+	}}`), 0o644))
+
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "composer.lock"), []byte(`{"packages": [
+		{"name": "onlishop/core", "version": "6.6.0.0", "license": ["GPL-3.0-only"], "require": {"symfony/http-kernel": "^7.0"}},
+		{"name": "symfony/http-kernel", "version": "v7.1.0", "license": ["MIT"]},
+		{"name": "symfony/polyfill-php83", "version": "v1.31.0", "license": ["MIT"]},
+		{"name": "acme/dual", "version": "1.0.0", "license": ["GPL-3.0-only", "MIT"], "require": {"php": ">=8.1", "symfony/polyfill-php83": "^1.31", "acme/unlicensed": "^1.0"}},
+		{"name": "acme/copyleft", "version": "1.0.0", "license": ["AGPL-3.0-only"]},
+		{"name": "acme/excluded", "version": "1.0.0", "license": ["AGPL-3.0-only"]},
+		{"name": "acme/unlicensed", "version": "1.0.0"}
+	]}`), 0o644))
+
+	adminDir := filepath.Join(extensionDir, "src", "Resources", "app", "administration")
+	require.NoError(t, os.MkdirAll(adminDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(adminDir, "package-lock.json"), []byte(`{"lockfileVersion": 3, "packages": {
+		"node_modules/lodash": {"version": "4.17.21", "license": "MIT"},
+		"node_modules/internal": {"version": "1.0.0", "license": "SEE LICENSE IN LICENSE.md"}
+	}}`), 0o644))
+
+	ext := &mockExtension{path: extensionDir, config: &Config{}}
+	ext.config.Validation.License.Deny = []string{"MIT"}
+	ext.config.Build.Zip.Composer.Enabled = true
+	ext.config.Build.Zip.Composer.ExcludedPackages = []string{"acme/excluded"}
+
+	check := &testCheck{}
+	validateDependencyLicenses(ext, check)
+
+	messages := make([]string, 0, len(check.Results))
+	for _, result := range check.Results {
+		assert.Equal(t, "license.dependencies", result.Identifier)
+		messages = append(messages, result.Path+": "+result.Message)
+	}
+
+	assert.Equal(t, []string{
+		"composer.lock: The composer package symfony/polyfill-php83@1.31.0 cannot be bundled: the license MIT is denied",
+		"composer.lock: The composer package acme/dual@1.0.0 cannot be bundled: the license MIT is denied",
+		"composer.lock: The composer package acme/copyleft@1.0.0 cannot be bundled: the license AGPL-3.0-only is incompatible with the extension license MIT",
+		"composer.lock: The composer package acme/unlicensed has no license",
+		"src/Resources/app/administration/package-lock.json: The license SEE LICENSE IN LICENSE.md of the npm package internal is not a valid SPDX expression",
+		"src/Resources/app/administration/package-lock.json: The npm package lodash@4.17.21 cannot be bundled: the license MIT is denied",
+	}, messages)
+}
+
+func TestValidateDependencyLicensesWithoutLockFiles(t *testing.T) {
+	check := &testCheck{}
+	validateDependencyLicenses(&mockExtension{path: t.TempDir(), config: &Config{}}, check)
+
+	assert.Empty(t, check.Results)
+}
+
+func TestValidateDependencyLicensesWithoutComposerBuild(t *testing.T) {
+	extensionDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "composer.json"), []byte(`{"require": {"acme/copyleft": "^1.0"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "composer.lock"), []byte(`{"packages": [
+		{"name": "acme/copyleft", "version": "1.0.0", "license": ["AGPL-3.0-only"]}
+	]}`), 0o644))
+
+	check := &testCheck{}
+	validateDependencyLicenses(&mockExtension{path: extensionDir, config: &Config{}}, check)
+
+	assert.Empty(t, check.Results)
+}
@@ -3,6 +3,7 @@ package extension
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
@@ -18,6 +19,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/shyim/go-version"
 	"github.com/zeebo/xxh3"
@@ -187,17 +189,38 @@ func GenerateChecksumJSON(ctx context.Context, baseFolder string, ext Extension)
 		return nil
 	}
 
-	ignores := ext.GetExtensionConfig().Build.Zip.Checksum.Ignore
+	hashes, err := collectChecksums(baseFolder, ext.GetExtensionConfig().Build.Zip.Checksum.Ignore)
+	if err != nil {
+		return err
+	}
 
 	checksumData := ChecksumJSON{
 		Algorithm:        "xxh128",
-		Hashes:           make(map[string]string),
+		Hashes:           hashes,
 		Version:          "1.0.0",
 		ExtensionVersion: version.String(),
 	}
 
+	// Write checksum.json file
+	checksumJSON, err := json.Marshal(checksumData)
+	if err != nil {
+		return fmt.Errorf("marshal checksum data: %w", err)
+	}
+
+	checksumPath := filepath.Join(baseFolder, "checksum.json")
+	if err := os.WriteFile(checksumPath, checksumJSON, 0644); err != nil {
+		return fmt.Errorf("write checksum file: %w", err)
+	}
+
+	return nil
+}
+
+// collectChecksums calculates the xxh128 checksums of all files of the extension which are part of the checksum.json.
+func collectChecksums(baseFolder string, ignores []string) (map[string]string, error) {
+	hashes := make(map[string]string)
+
 	// Walk through all files in the folder and calculate checksums
-	err = filepath.Walk(baseFolder, func(path string, info fs.FileInfo, err error) error {
+	err := filepath.Walk(baseFolder, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -226,8 +249,8 @@ func GenerateChecksumJSON(ctx context.Context, baseFolder string, ext Extension)
 			return nil
 		}
 
-		// Skip checksum.json itself if it exists
-		if relPath == "checksum.json" {
+		// Skip checksum.json itself and its signature if they exist
+		if relPath == "checksum.json" || relPath == "checksum.json"+SignatureSuffix {
 			return nil
 		}
 
@@ -246,30 +269,39 @@ func GenerateChecksumJSON(ctx context.Context, baseFolder string, ext Extension)
 		relPath = filepath.ToSlash(relPath)
 
 		// Add to hashes map
-		checksumData.Hashes[relPath] = checksum
+		hashes[relPath] = checksum
 
 		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("walking directory for checksums: %w", err)
+		return nil, fmt.Errorf("walking directory for checksums: %w", err)
 	}
 
-	// Write checksum.json file
-	checksumJSON, err := json.Marshal(checksumData)
-	if err != nil {
-		return fmt.Errorf("marshal checksum data: %w", err)
-	}
+	return hashes, nil
+}
 
-	checksumPath := filepath.Join(baseFolder, "checksum.json")
-	if err := os.WriteFile(checksumPath, checksumJSON, 0644); err != nil {
-		return fmt.Errorf("write checksum file: %w", err)
-	}
+func CreateZip(baseFolder, zipFile string) error {
+	return createZip(baseFolder, zipFile, nil)
+}
 
-	return nil
+// CreateReproducibleZip creates a zip which is byte identical for the same folder content.
+// All entries get the given modification time and normalized permissions, so the zip does not depend on the checkout.
+func CreateReproducibleZip(baseFolder, zipFile string, modTime time.Time) error {
+	modTime = modTime.UTC()
+
+	return createZip(baseFolder, zipFile, func(header *zip.FileHeader) {
+		mode := os.FileMode(0o644)
+		if header.Mode()&0o111 != 0 {
+			mode = 0o755
+		}
+
+		header.Modified = modTime
+		header.SetMode(mode)
+	})
 }
 
-func CreateZip(baseFolder, zipFile string) error {
+func createZip(baseFolder, zipFile string, normalize func(header *zip.FileHeader)) error {
 	// Get a Buffer to Write To
 	outFile, err := os.Create(zipFile)
 	if err != nil {
@@ -283,14 +315,24 @@ func CreateZip(baseFolder, zipFile string) error {
 	// Create a new zip archive.
 	w := zip.NewWriter(outFile)
 
-	defer func() {
+	if err := addZipFiles(w, baseFolder, "", normalize); err != nil {
 		_ = w.Close()
-	}()
+		return err
+	}
 
-	return AddZipFiles(w, baseFolder, "")
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("create zipfile: %w", err)
+	}
+
+	return nil
 }
 
 func AddZipFiles(w *zip.Writer, basePath, baseInZip string) error {
+	return addZipFiles(w, basePath, baseInZip, nil)
+}
+
+func addZipFiles(w *zip.Writer, basePath, baseInZip string, normalize func(header *zip.FileHeader)) error {
+	// os.ReadDir returns the entries sorted by name, so the order of the zip entries is stable
 	files, err := os.ReadDir(basePath)
 	if err != nil {
 		return fmt.Errorf("could not zip dir, basePath: %q, baseInZip: %q, %w", basePath, baseInZip, err)
@@ -299,11 +341,11 @@ func AddZipFiles(w *zip.Writer, basePath, baseInZip string) error {
 	for _, file := range files {
 		if file.IsDir() {
 			// Add files of directory recursively
-			if err = AddZipFiles(w, filepath.Join(basePath, file.Name()), filepath.Join(baseInZip, file.Name())); err != nil {
+			if err = addZipFiles(w, filepath.Join(basePath, file.Name()), filepath.Join(baseInZip, file.Name()), normalize); err != nil {
 				return err
 			}
 		} else {
-			if err = addFileToZip(w, filepath.Join(basePath, file.Name()), filepath.Join(baseInZip, file.Name())); err != nil {
+			if err = addFileToZip(w, filepath.Join(basePath, file.Name()), filepath.Join(baseInZip, file.Name()), normalize); err != nil {
 				return err
 			}
 		}
@@ -312,6 +354,25 @@ func AddZipFiles(w *zip.Writer, basePath, baseInZip string) error {
 	return nil
 }
 
+// FileSHA256 returns the hex encoded sha256 hash of the file.
+func FileSHA256(file string) (string, error) {
+	handle, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = handle.Close()
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, handle); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func CleanupExtensionFolder(path string, additionalPaths []string) error {
 	defaultNotAllowedPaths = append(defaultNotAllowedPaths, additionalPaths...)
 
@@ -436,7 +497,7 @@ func PrepareFolderForZipping(ctx context.Context, path string, ext Extension, ex
 	return nil
 }
 
-func addFileToZip(zipWriter *zip.Writer, sourcePath string, zipPath string) error {
+func addFileToZip(zipWriter *zip.Writer, sourcePath string, zipPath string, normalize func(header *zip.FileHeader)) error {
 	zipErrorFormat := "could not zip file, sourcePath: %q, zipPath: %q, %w"
 
 	file, err := os.Open(sourcePath)
@@ -460,6 +521,10 @@ func addFileToZip(zipWriter *zip.Writer, sourcePath string, zipPath string) erro
 	header.Name = zipPath
 	header.Method = zip.Deflate
 
+	if normalize != nil {
+		normalize(header)
+	}
+
 	f, err := zipWriter.CreateHeader(header)
 	if err != nil {
 		return fmt.Errorf(zipErrorFormat, sourcePath, zipPath, err)
@@ -0,0 +1,206 @@
+package extension
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onlishop/onlishop-cli/internal/packagist"
+)
+
+const (
+	ZipDiffAdded    = "added"
+	ZipDiffRemoved  = "removed"
+	ZipDiffModified = "modified"
+)
+
+// ZipDiff describes what changed between the shipped files of two releases of an extension.
+type ZipDiff struct {
+	OldVersion    string
+	NewVersion    string
+	OldConstraint string
+	NewConstraint string
+	Files         []ZipDiffFile
+	Requirements  []ZipDiffRequirement
+	OldAssetSize  int64
+	NewAssetSize  int64
+}
+
+// ZipDiffFile is a file which has been added, removed or modified.
+type ZipDiffFile struct {
+	Path    string
+	Change  string
+	OldSize int64
+	NewSize int64
+}
+
+// ZipDiffRequirement is a composer requirement with a changed constraint, an empty constraint means not required.
+type ZipDiffRequirement struct {
+	Package string
+	Old     string
+	New     string
+}
+
+// NewPHPFiles returns the PHP files which are only part of the new release.
+func (d ZipDiff) NewPHPFiles() []string {
+	files := make([]string, 0)
+
+	for _, file := range d.Files {
+		if file.Change == ZipDiffAdded && strings.HasSuffix(file.Path, ".php") {
+			files = append(files, file.Path)
+		}
+	}
+
+	return files
+}
+
+type zipDiffEntry struct {
+	size     int64
+	checksum string
+}
+
+// DiffExtensions compares the files, composer requirements and version constraints of two extracted releases.
+func DiffExtensions(oldExt, newExt Extension) (*ZipDiff, error) {
+	diff := &ZipDiff{
+		OldVersion:    extensionVersionString(oldExt),
+		NewVersion:    extensionVersionString(newExt),
+		OldConstraint: extensionConstraintString(oldExt),
+		NewConstraint: extensionConstraintString(newExt),
+		Files:         make([]ZipDiffFile, 0),
+		Requirements:  diffRequirements(composerRequirements(oldExt.GetPath()), composerRequirements(newExt.GetPath())),
+	}
+
+	oldFiles, err := collectZipDiffEntries(oldExt.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	newFiles, err := collectZipDiffEntries(newExt.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	for file, entry := range newFiles {
+		if isShippedAsset(file) {
+			diff.NewAssetSize += entry.size
+		}
+
+		oldEntry, ok := oldFiles[file]
+
+		if !ok {
+			diff.Files = append(diff.Files, ZipDiffFile{Path: file, Change: ZipDiffAdded, NewSize: entry.size})
+		} else if oldEntry.checksum != entry.checksum {
+			diff.Files = append(diff.Files, ZipDiffFile{Path: file, Change: ZipDiffModified, OldSize: oldEntry.size, NewSize: entry.size})
+		}
+	}
+
+	for file, entry := range oldFiles {
+		if isShippedAsset(file) {
+			diff.OldAssetSize += entry.size
+		}
+
+		if _, ok := newFiles[file]; !ok {
+			diff.Files = append(diff.Files, ZipDiffFile{Path: file, Change: ZipDiffRemoved, OldSize: entry.size})
+		}
+	}
+
+	sort.Slice(diff.Files, func(i, j int) bool {
+		return diff.Files[i].Path < diff.Files[j].Path
+	})
+
+	return diff, nil
+}
+
+func collectZipDiffEntries(folder string) (map[string]zipDiffEntry, error) {
+	entries := make(map[string]zipDiffEntry)
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		checksum, err := ChecksumFile(path)
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+
+		entries[filepath.ToSlash(relPath)] = zipDiffEntry{size: info.Size(), checksum: checksum}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("collect files of %s: %w", folder, err)
+	}
+
+	return entries, nil
+}
+
+// isShippedAsset reports whether the file is a compiled administration or storefront asset.
+func isShippedAsset(file string) bool {
+	return strings.Contains(file, "Resources/public/") || strings.Contains(file, "Resources/app/storefront/dist/")
+}
+
+func composerRequirements(folder string) map[string]string {
+	composerJson, err := packagist.ReadComposerJson(filepath.Join(folder, "composer.json"))
+	if err != nil {
+		return map[string]string{}
+	}
+
+	return composerJson.Require
+}
+
+func diffRequirements(oldRequire, newRequire map[string]string) []ZipDiffRequirement {
+	requirements := make([]ZipDiffRequirement, 0)
+
+	for name, constraint := range newRequire {
+		if oldRequire[name] != constraint {
+			requirements = append(requirements, ZipDiffRequirement{Package: name, Old: oldRequire[name], New: constraint})
+		}
+	}
+
+	for name, constraint := range oldRequire {
+		if _, ok := newRequire[name]; !ok {
+			requirements = append(requirements, ZipDiffRequirement{Package: name, Old: constraint})
+		}
+	}
+
+	sort.Slice(requirements, func(i, j int) bool {
+		return requirements[i].Package < requirements[j].Package
+	})
+
+	return requirements
+}
+
+func extensionVersionString(ext Extension) string {
+	extVersion, err := ext.GetVersion()
+	if err != nil {
+		return ""
+	}
+
+	return extVersion.String()
+}
+
+func extensionConstraintString(ext Extension) string {
+	constraint, err := ext.GetOnlishopVersionConstraint()
+	if err != nil {
+		return ""
+	}
+
+	return constraint.String()
+}
@@ -0,0 +1,70 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeZipDiffRelease(t *testing.T, files map[string]string) Extension {
+	t.Helper()
+
+	folder := t.TempDir()
+
+	for file, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(folder, file)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(folder, file), []byte(content), 0o644))
+	}
+
+	ext, err := GetExtensionByFolder(folder)
+	require.NoError(t, err)
+
+	return ext
+}
+
+func TestDiffExtensions(t *testing.T) {
+	oldExt := writeZipDiffRelease(t, map[string]string{
+		"composer.json": `{"name": "frosh/tools", "version": "1.0.0", "type": "onlishop-platform-plugin", "require": {"onlishop/core": "~6.5.0", "acme/removed": "^1.0"}, "extra": {"onlishop-plugin-class": "Frosh\\Tools"}}`,
+		"src/Tools.php": "<?php class Tools {}",
+		"src/Resources/public/administration/js/tools.js": "console.log(1)",
+		"src/Resources/config/removed.xml":                "<config/>",
+	})
+
+	newExt := writeZipDiffRelease(t, map[string]string{
+		"composer.json":   `{"name": "frosh/tools", "version": "1.1.0", "type": "onlishop-platform-plugin", "require": {"onlishop/core": "~6.6.0", "acme/added": "^2.0"}, "extra": {"onlishop-plugin-class": "Frosh\\Tools"}}`,
+		"src/Tools.php":   "<?php class Tools {}",
+		"src/Command.php": "<?php class Command {}",
+		"src/Resources/public/administration/js/tools.js": "console.log(12345)",
+	})
+
+	diff, err := DiffExtensions(oldExt, newExt)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0", diff.OldVersion)
+	assert.Equal(t, "1.1.0", diff.NewVersion)
+	assert.NotEqual(t, diff.OldConstraint, diff.NewConstraint)
+
+	assert.Equal(t, []ZipDiffRequirement{
+		{Package: "acme/added", New: "^2.0"},
+		{Package: "acme/removed", Old: "^1.0"},
+		{Package: "onlishop/core", Old: "~6.5.0", New: "~6.6.0"},
+	}, diff.Requirements)
+
+	changes := make(map[string]string)
+	for _, file := range diff.Files {
+		changes[file.Path] = file.Change
+	}
+
+	assert.Equal(t, map[string]string{
+		"composer.json":                                   ZipDiffModified,
+		"src/Command.php":                                 ZipDiffAdded,
+		"src/Resources/config/removed.xml":                ZipDiffRemoved,
+		"src/Resources/public/administration/js/tools.js": ZipDiffModified,
+	}, changes)
+
+	assert.Equal(t, []string{"src/Command.php"}, diff.NewPHPFiles())
+	assert.Equal(t, int64(4), diff.NewAssetSize-diff.OldAssetSize)
+}
@@ -1,10 +1,12 @@
 package extension
 
 import (
+	"archive/zip"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/shyim/go-version"
 	"github.com/stretchr/testify/assert"
@@ -183,3 +185,78 @@ func TestGenerateChecksumJSONIgnores(t *testing.T) {
 	assert.Contains(t, checksum.Hashes, "composer.json", "composer.json should be in the checksum list")
 	assert.NotContains(t, checksum.Hashes, "src/Resources/test.txt", "src/Resources/test.txt should be in the checksum list")
 }
+
+func writeReproducibleZipFixture(t *testing.T, modTime time.Time, scriptMode os.FileMode) string {
+	t.Helper()
+
+	folder := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "src", "Resources"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "composer.json"), []byte(`{"name": "acme/example"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "src", "Resources", "config.xml"), []byte("<config/>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "bin.sh"), []byte("#!/bin/sh"), scriptMode))
+
+	require.NoError(t, filepath.Walk(folder, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		return os.Chtimes(path, modTime, modTime)
+	}))
+
+	return folder
+}
+
+func TestCreateReproducibleZip(t *testing.T) {
+	buildTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	first := filepath.Join(t.TempDir(), "first.zip")
+	second := filepath.Join(t.TempDir(), "second.zip")
+
+	require.NoError(t, CreateReproducibleZip(writeReproducibleZipFixture(t, time.Now(), 0o755), first, buildTime))
+	require.NoError(t, CreateReproducibleZip(writeReproducibleZipFixture(t, time.Now().Add(-time.Hour), 0o700), second, buildTime))
+
+	firstHash, err := FileSHA256(first)
+	require.NoError(t, err)
+
+	secondHash, err := FileSHA256(second)
+	require.NoError(t, err)
+
+	assert.Equal(t, firstHash, secondHash)
+
+	reader, err := zip.OpenReader(first)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	names := make([]string, 0, len(reader.File))
+
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+
+		assert.True(t, buildTime.Equal(file.Modified), file.Name)
+
+		if file.Name == "bin.sh" {
+			assert.Equal(t, os.FileMode(0o755), file.Mode())
+		} else {
+			assert.Equal(t, os.FileMode(0o644), file.Mode())
+		}
+	}
+
+	assert.Equal(t, []string{"bin.sh", "composer.json", filepath.Join("src", "Resources", "config.xml")}, names)
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1714564800")
+
+	buildTime, err := SourceDateEpoch(t.Context(), t.TempDir(), "")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), buildTime)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+
+	_, err = SourceDateEpoch(t.Context(), t.TempDir(), "")
+	assert.ErrorContains(t, err, "invalid SOURCE_DATE_EPOCH")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -30,6 +31,13 @@ type AssetCompileOptions struct {
 	OutputCSSFile   string
 	StaticSourceDir string
 	StaticTargetDir string
+	// Alias and NodePaths allow resolving imports of the Onlishop Storefront sources without webpack
+	Alias     map[string]string
+	NodePaths []string
+	// LogOutput receives the esbuild warnings and errors instead of stderr when set
+	LogOutput io.Writer
+	// OnBuildEnd is called after every build, including rebuilds of a watching context
+	OnBuildEnd func(result *api.BuildResult)
 }
 
 const DotJs = ".js"
@@ -102,6 +110,16 @@ func getEsbuildOptions(ctx context.Context, options AssetCompileOptions) (*api.B
 		loader[".scss"] = api.LoaderCSS
 	}
 
+	if options.OnBuildEnd != nil {
+		plugins = append(plugins, newBuildEndPlugin(options.OnBuildEnd))
+	}
+
+	logLevel := api.LogLevelWarning
+
+	if options.LogOutput != nil {
+		logLevel = api.LogLevelSilent
+	}
+
 	bundlerOptions := api.BuildOptions{
 		MinifySyntax:      options.ProductionMode,
 		MinifyWhitespace:  options.ProductionMode,
@@ -110,14 +128,29 @@ func getEsbuildOptions(ctx context.Context, options AssetCompileOptions) (*api.B
 		Outfile:           "extension.js",
 		Bundle:            true,
 		Write:             false,
-		LogLevel:          api.LogLevelWarning,
+		LogLevel:          logLevel,
 		Plugins:           plugins,
 		Loader:            loader,
+		Alias:             options.Alias,
+		NodePaths:         options.NodePaths,
 	}
 
 	return &bundlerOptions, nil
 }
 
+func newBuildEndPlugin(onBuildEnd func(result *api.BuildResult)) api.Plugin {
+	return api.Plugin{
+		Name: "build-end",
+		Setup: func(build api.PluginBuild) {
+			build.OnEnd(func(result *api.BuildResult) (api.OnEndResult, error) {
+				onBuildEnd(result)
+
+				return api.OnEndResult{}, nil
+			})
+		},
+	}
+}
+
 func Context(ctx context.Context, options AssetCompileOptions) (api.BuildContext, *api.ContextError) {
 	bundlerOptions, err := getEsbuildOptions(ctx, options)
 	if err != nil {
@@ -138,6 +171,10 @@ func CompileExtensionAsset(ctx context.Context, options AssetCompileOptions) (*A
 
 	result := api.Build(*bundlerOptions)
 
+	if options.LogOutput != nil {
+		writeBuildMessages(options.LogOutput, result)
+	}
+
 	if len(result.Errors) > 0 {
 		return nil, fmt.Errorf("initial compile failed")
 	}
@@ -166,6 +203,20 @@ func CompileExtensionAsset(ctx context.Context, options AssetCompileOptions) (*A
 	return &compileResult, nil
 }
 
+func writeBuildMessages(w io.Writer, result api.BuildResult) {
+	formatOptions := api.FormatMessagesOptions{TerminalWidth: 120}
+
+	formatOptions.Kind = api.ErrorMessage
+	for _, msg := range api.FormatMessages(result.Errors, formatOptions) {
+		_, _ = io.WriteString(w, msg)
+	}
+
+	formatOptions.Kind = api.WarningMessage
+	for _, msg := range api.FormatMessages(result.Warnings, formatOptions) {
+		_, _ = io.WriteString(w, msg)
+	}
+}
+
 func cleanupOutputFolder(options AssetCompileOptions) error {
 	folders := []string{"css", "js"}
 
@@ -8,6 +8,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sync"
+
+	"github.com/bep/godartsass/v2"
 
 	"github.com/onlishop/onlishop-cli/internal/system"
 	"github.com/onlishop/onlishop-cli/logging"
@@ -21,7 +24,26 @@ var scssVariables []byte
 //go:embed static/mixins.scss
 var scssMixins []byte
 
+func startDartSass(ctx context.Context) (*godartsass.Transpiler, error) {
+	dartSassBinary, err := locateDartSass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return godartsass.Start(godartsass.Options{
+		DartSassEmbeddedFilename: dartSassBinary,
+		Timeout:                  0,
+		LogEventHandler:          nil,
+	})
+}
+
+// dartSassLock prevents concurrent builds from downloading dart-sass multiple times
+var dartSassLock sync.Mutex
+
 func locateDartSass(ctx context.Context) (string, error) {
+	dartSassLock.Lock()
+	defer dartSassLock.Unlock()
+
 	if exePath, err := exec.LookPath("dart-sass"); err == nil {
 		return exePath, nil
 	}
@@ -16,16 +16,7 @@ func newScssPlugin(ctx context.Context) api.Plugin {
 	return api.Plugin{
 		Name: "scss",
 		Setup: func(build api.PluginBuild) {
-			dartSassBinary, err := locateDartSass(ctx)
-			if err != nil {
-				logging.FromContext(ctx).Fatalln(err)
-			}
-
-			start, err := godartsass.Start(godartsass.Options{
-				DartSassEmbeddedFilename: dartSassBinary,
-				Timeout:                  0,
-				LogEventHandler:          nil,
-			})
+			start, err := startDartSass(ctx)
 			if err != nil {
 				logging.FromContext(ctx).Fatalln(err)
 			}
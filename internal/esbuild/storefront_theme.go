@@ -0,0 +1,171 @@
+package esbuild
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bep/godartsass/v2"
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+// StorefrontThemeOptions describes the SCSS compilation of a storefront theme.
+type StorefrontThemeOptions struct {
+	// Styles are absolute paths to SCSS files, imported in the given order
+	Styles []string
+	// Variables are declared as SCSS variables before the first style is imported
+	Variables map[string]string
+	// VendorPaths are searched for imports prefixed with ~
+	VendorPaths    []string
+	ProductionMode bool
+}
+
+// CompileStorefrontTheme compiles all theme styles into a single stylesheet using the embedded dart-sass.
+func CompileStorefrontTheme(ctx context.Context, options StorefrontThemeOptions) (string, error) {
+	transpiler, err := startDartSass(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = transpiler.Close()
+	}()
+
+	result, err := transpiler.Execute(godartsass.Args{
+		Source:         BuildStorefrontThemeSource(options),
+		URL:            "file://internal//theme.scss",
+		ImportResolver: themeImporter{vendorPaths: options.VendorPaths},
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot compile theme: %w", err)
+	}
+
+	if !options.ProductionMode {
+		return result.CSS, nil
+	}
+
+	minified := api.Transform(result.CSS, api.TransformOptions{
+		Loader:           api.LoaderCSS,
+		MinifyWhitespace: true,
+		MinifySyntax:     true,
+	})
+
+	if len(minified.Errors) > 0 {
+		return "", fmt.Errorf("cannot minify theme: %s", minified.Errors[0].Text)
+	}
+
+	return string(minified.Code), nil
+}
+
+// BuildStorefrontThemeSource generates the SCSS entrypoint containing the variables and imports of the theme.
+func BuildStorefrontThemeSource(options StorefrontThemeOptions) string {
+	var source strings.Builder
+
+	names := make([]string, 0, len(options.Variables))
+	for name := range options.Variables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(&source, "$%s: %s;\n", name, options.Variables[name])
+	}
+
+	for _, style := range options.Styles {
+		fmt.Fprintf(&source, "@import %q;\n", "file://"+filepath.ToSlash(style))
+	}
+
+	return source.String()
+}
+
+type themeImporter struct {
+	vendorPaths []string
+}
+
+func (i themeImporter) CanonicalizeURL(url string) (string, error) {
+	if url == "~scss/variables" || url == "~scss/variables.scss" {
+		return InternalVariablesScssPath, nil
+	}
+
+	if url == "~scss/mixins" || url == "~scss/mixins.scss" {
+		return InternalMixinsScssPath, nil
+	}
+
+	if strings.HasPrefix(url, "file://") {
+		if file := resolveScssFile(filepath.FromSlash(strings.TrimPrefix(url, "file://"))); file != "" {
+			return "file://" + filepath.ToSlash(file), nil
+		}
+
+		return "", nil
+	}
+
+	if !strings.HasPrefix(url, "~") {
+		return "", nil
+	}
+
+	name := strings.TrimPrefix(url, "~")
+
+	for _, vendorPath := range i.vendorPaths {
+		if file := resolveScssFile(filepath.Join(vendorPath, name)); file != "" {
+			return "file://" + filepath.ToSlash(file), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (i themeImporter) Load(canonicalizedURL string) (godartsass.Import, error) {
+	if canonicalizedURL == InternalVariablesScssPath || canonicalizedURL == InternalMixinsScssPath {
+		return scssImporter{}.Load(canonicalizedURL)
+	}
+
+	file := strings.TrimPrefix(canonicalizedURL, "file://")
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return godartsass.Import{}, err
+	}
+
+	syntax := godartsass.SourceSyntaxSCSS
+
+	switch filepath.Ext(file) {
+	case ".css":
+		syntax = godartsass.SourceSyntaxCSS
+	case ".sass":
+		syntax = godartsass.SourceSyntaxSASS
+	}
+
+	return godartsass.Import{
+		Content:      string(content),
+		SourceSyntax: syntax,
+	}, nil
+}
+
+// resolveScssFile looks up a Sass import the same way dart-sass does for partials and index files.
+func resolveScssFile(file string) string {
+	dir, base := filepath.Split(file)
+
+	candidates := []string{file}
+
+	if filepath.Ext(base) == "" {
+		for _, ext := range []string{".scss", ".sass", ".css"} {
+			candidates = append(candidates, filepath.Join(dir, base+ext), filepath.Join(dir, "_"+base+ext))
+		}
+
+		candidates = append(candidates, filepath.Join(file, "_index.scss"), filepath.Join(file, "index.scss"))
+	} else {
+		candidates = append(candidates, filepath.Join(dir, "_"+base))
+	}
+
+	for _, candidate := range candidates {
+		if stat, err := os.Stat(candidate); err == nil && !stat.IsDir() {
+			return candidate
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,59 @@
+package esbuild
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStorefrontThemeSource(t *testing.T) {
+	source := BuildStorefrontThemeSource(StorefrontThemeOptions{
+		Styles: []string{"/theme/base.scss", "/plugin/base.scss"},
+		Variables: map[string]string{
+			"sw-color-brand-primary": "#0042a0",
+			"sw-asset-theme-url":     "''",
+		},
+	})
+
+	assert.Equal(t, "$sw-asset-theme-url: '';\n$sw-color-brand-primary: #0042a0;\n@import \"file:///theme/base.scss\";\n@import \"file:///plugin/base.scss\";\n", source)
+}
+
+func TestThemeImporterResolvesVendorImports(t *testing.T) {
+	vendor := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(vendor, "bootstrap", "scss"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, "bootstrap", "scss", "_functions.scss"), []byte(""), os.ModePerm))
+
+	importer := themeImporter{vendorPaths: []string{filepath.Join(t.TempDir(), "missing"), vendor}}
+
+	url, err := importer.CanonicalizeURL("~bootstrap/scss/functions")
+	assert.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.ToSlash(filepath.Join(vendor, "bootstrap", "scss", "_functions.scss")), url)
+
+	url, err = importer.CanonicalizeURL("~unknown/package")
+	assert.NoError(t, err)
+	assert.Empty(t, url)
+
+	url, err = importer.CanonicalizeURL("~scss/variables")
+	assert.NoError(t, err)
+	assert.Equal(t, InternalVariablesScssPath, url)
+}
+
+func TestThemeImporterResolvesFileURLs(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "abstract"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "abstract", "_index.scss"), []byte(".a { color: red; }"), os.ModePerm))
+
+	importer := themeImporter{}
+
+	url, err := importer.CanonicalizeURL("file://" + filepath.ToSlash(filepath.Join(dir, "abstract")))
+	assert.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.ToSlash(filepath.Join(dir, "abstract", "_index.scss")), url)
+
+	loaded, err := importer.Load(url)
+	assert.NoError(t, err)
+	assert.Equal(t, ".a { color: red; }", loaded.Content)
+}
@@ -195,3 +195,43 @@ func unshallowRepository(ctx context.Context, repo string) error {
 
 	return err
 }
+
+// GetChangedFiles returns the files added or modified since the merge base of ref and HEAD, including uncommitted
+// and untracked files. The paths are relative to the given directory.
+func GetChangedFiles(ctx context.Context, repo, ref string) ([]string, error) {
+	mergeBase, err := runGit(ctx, repo, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("cannot find merge base of %s: %w", ref, err)
+	}
+
+	changed, err := runGit(ctx, repo, "diff", "--name-only", "--relative", "--diff-filter=ACMR", strings.TrimSpace(mergeBase))
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := runGit(ctx, repo, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	for _, line := range strings.Split(changed+"\n"+untracked, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if _, ok := seen[line]; ok {
+			continue
+		}
+
+		seen[line] = struct{}{}
+		files = append(files, line)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
@@ -112,3 +112,33 @@ func prepareRepository(t *testing.T, tmpDir string) {
 	runCommand(t, tmpDir, "config", "user.name", "test")
 	runCommand(t, tmpDir, "config", "user.email", "test@test.de")
 }
+
+func TestGetChangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	prepareRepository(t, tmpDir)
+	_ = os.MkdirAll(filepath.Join(tmpDir, "src"), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(tmpDir, "src", "a.php"), []byte(""), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(tmpDir, "src", "b.php"), []byte(""), os.ModePerm)
+	runCommand(t, tmpDir, "add", ".")
+	runCommand(t, tmpDir, "commit", "-m", "initial commit", "--no-verify", "--no-gpg-sign")
+	runCommand(t, tmpDir, "tag", "base")
+
+	_ = os.WriteFile(filepath.Join(tmpDir, "src", "c.php"), []byte(""), os.ModePerm)
+	runCommand(t, tmpDir, "add", ".")
+	runCommand(t, tmpDir, "commit", "-m", "second commit", "--no-verify", "--no-gpg-sign")
+
+	// Uncommitted and untracked changes are included too
+	_ = os.WriteFile(filepath.Join(tmpDir, "src", "a.php"), []byte("<?php"), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(tmpDir, "src", "d.php"), []byte(""), os.ModePerm)
+
+	files, err := GetChangedFiles(t.Context(), tmpDir, "base")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/a.php", "src/c.php", "src/d.php"}, files)
+
+	files, err = GetChangedFiles(t.Context(), filepath.Join(tmpDir, "src"), "base")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.php", "c.php", "d.php"}, files)
+
+	_, err = GetChangedFiles(t.Context(), tmpDir, "does-not-exist")
+	assert.Error(t, err)
+}
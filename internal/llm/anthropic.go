@@ -0,0 +1,201 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+const (
+	anthropicVersion          = "2023-06-01"
+	anthropicDefaultMaxTokens = 8192
+)
+
+// AnthropicClient represents a client for the Anthropic Messages API and compatible gateways.
+type AnthropicClient struct {
+	host   string
+	apiKey string
+	client *http.Client
+}
+
+type AnthropicRequest struct {
+	Model     string        `json:"model"`
+	System    string        `json:"system,omitempty"`
+	Messages  []ChatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens"`
+	Stream    bool          `json:"stream,omitempty"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type AnthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage anthropicUsage `json:"usage"`
+}
+
+// AnthropicEvent represents one server sent event of a streamed message.
+type AnthropicEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage *anthropicUsage `json:"usage"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newAnthropicClient(config Config) *AnthropicClient {
+	host := config.BaseURL
+	if host == "" {
+		host = firstEnv("ANTHROPIC_BASE_URL")
+	}
+
+	if host == "" {
+		host = "https://api.anthropic.com"
+	}
+
+	apiKey := config.APIKey
+	if apiKey == "" {
+		apiKey = firstEnv("ANTHROPIC_API_KEY")
+	}
+
+	return &AnthropicClient{
+		host:   strings.TrimSuffix(host, "/"),
+		apiKey: apiKey,
+		client: &http.Client{
+			Timeout: 300 * time.Second,
+		},
+	}
+}
+
+func (c *AnthropicClient) complete(ctx context.Context, prompt string, options *LLMOptions, onDelta func(delta string)) (string, Usage, error) {
+	reqBody := AnthropicRequest{
+		Model:     options.Model,
+		System:    schemaInstruction(options),
+		Messages:  []ChatMessage{{Role: "user", Content: prompt}},
+		MaxTokens: options.MaxTokens,
+		Stream:    onDelta != nil,
+	}
+
+	if reqBody.MaxTokens == 0 {
+		reqBody.MaxTokens = anthropicDefaultMaxTokens
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/messages", c.host), bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("anthropic-version", anthropicVersion)
+	if c.apiKey != "" {
+		req.Header.Set("x-api-key", c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logging.FromContext(ctx).Warnf("failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", Usage{}, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return "", Usage{}, newStatusError(resp, body)
+	}
+
+	if onDelta != nil {
+		return c.readStream(resp.Body, onDelta)
+	}
+
+	var response AnthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", Usage{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	usage := Usage{InputTokens: response.Usage.InputTokens, OutputTokens: response.Usage.OutputTokens}
+
+	var text strings.Builder
+	for _, content := range response.Content {
+		if content.Type == "text" {
+			text.WriteString(content.Text)
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", usage, fmt.Errorf("no text content returned")
+	}
+
+	return text.String(), usage, nil
+}
+
+func (c *AnthropicClient) readStream(body io.Reader, onDelta func(delta string)) (string, Usage, error) {
+	var text strings.Builder
+	var usage Usage
+
+	err := readServerSentEvents(body, func(data string) error {
+		var event AnthropicEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("failed to decode stream event: %w", err)
+		}
+
+		switch event.Type {
+		case "message_start":
+			usage.InputTokens = event.Message.Usage.InputTokens
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" {
+				text.WriteString(event.Delta.Text)
+				onDelta(event.Delta.Text)
+			}
+		case "message_delta":
+			if event.Usage != nil {
+				usage.OutputTokens = event.Usage.OutputTokens
+			}
+		case "error":
+			if event.Error != nil && event.Error.Type == "overloaded_error" {
+				return &StatusError{StatusCode: 529, Body: event.Error.Message}
+			}
+
+			if event.Error != nil {
+				return fmt.Errorf("stream error %s: %s", event.Error.Type, event.Error.Message)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", usage, err
+	}
+
+	return text.String(), usage, nil
+}
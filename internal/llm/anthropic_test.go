@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnthropicGenerate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+
+		var request AnthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		assert.Equal(t, "claude-test", request.Model)
+		assert.Equal(t, anthropicDefaultMaxTokens, request.MaxTokens)
+		assert.Contains(t, request.System, "You are a helpful assistant")
+		assert.Contains(t, request.System, `{"type":"object"}`)
+
+		_, _ = w.Write([]byte(`{"content": [{"type": "text", "text": "{}"}], "usage": {"input_tokens": 12, "output_tokens": 3}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewLLMClientFromConfig(Config{Provider: "anthropic", BaseURL: server.URL, APIKey: "test-key"})
+	require.NoError(t, err)
+
+	text, err := client.Generate(t.Context(), "Hello", &LLMOptions{
+		Model:          "claude-test",
+		SystemPrompt:   "You are a helpful assistant",
+		ResponseSchema: json.RawMessage(`{"type":"object"}`),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "{}", text)
+	assert.Equal(t, Usage{Requests: 1, InputTokens: 12, OutputTokens: 3}, client.Usage())
+}
+
+func TestAnthropicStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request AnthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.True(t, request.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		events := []string{
+			`{"type": "message_start", "message": {"usage": {"input_tokens": 20, "output_tokens": 1}}}`,
+			`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Hello"}}`,
+			`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": " world"}}`,
+			`{"type": "message_delta", "delta": {"stop_reason": "end_turn"}, "usage": {"output_tokens": 5}}`,
+			`{"type": "message_stop"}`,
+		}
+
+		for _, event := range events {
+			_, _ = w.Write([]byte("event: message\ndata: " + event + "\n\n"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewLLMClientFromConfig(Config{Provider: "anthropic", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	var deltas []string
+
+	text, err := client.Stream(t.Context(), "Hello", &LLMOptions{Model: "claude-test"}, func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello world", text)
+	assert.Equal(t, "Hello world", strings.Join(deltas, ""))
+	assert.Len(t, deltas, 2)
+	assert.Equal(t, 20, client.Usage().InputTokens)
+	assert.Equal(t, 5, client.Usage().OutputTokens)
+}
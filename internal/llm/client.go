@@ -0,0 +1,143 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+// StatusError is returned when an API responds with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Retryable reports whether the request can succeed when it is sent again later.
+func (e *StatusError) Retryable() bool {
+	switch e.StatusCode {
+	// 529 is used by Anthropic for overloaded servers
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, 529:
+		return true
+	}
+
+	return false
+}
+
+func newStatusError(resp *http.Response, body []byte) *StatusError {
+	statusErr := &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		statusErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return statusErr
+}
+
+// client retries rate limited requests with an exponential backoff and sums up the usage of all requests.
+type client struct {
+	provider   provider
+	maxRetries int
+	retryDelay time.Duration
+	pricing    Pricing
+
+	mutex sync.Mutex
+	usage Usage
+}
+
+func (c *client) Generate(ctx context.Context, prompt string, options *LLMOptions) (string, error) {
+	return c.Stream(ctx, prompt, options, nil)
+}
+
+func (c *client) Stream(ctx context.Context, prompt string, options *LLMOptions, onDelta func(delta string)) (string, error) {
+	if options == nil {
+		options = &LLMOptions{}
+	}
+
+	delay := c.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		text, usage, err := c.provider.complete(ctx, prompt, options, onDelta)
+
+		c.addUsage(usage)
+
+		if err == nil {
+			return text, nil
+		}
+
+		var statusErr *StatusError
+		if attempt >= c.maxRetries || !errors.As(err, &statusErr) || !statusErr.Retryable() {
+			return "", err
+		}
+
+		wait := delay
+		if statusErr.RetryAfter > 0 {
+			wait = statusErr.RetryAfter
+		}
+
+		logging.FromContext(ctx).Warnf("Request failed with status code %d, retrying in %s", statusErr.StatusCode, wait)
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+	}
+}
+
+func (c *client) addUsage(usage Usage) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.usage.Requests++
+	c.usage.InputTokens += usage.InputTokens
+	c.usage.OutputTokens += usage.OutputTokens
+	c.usage.Cost = c.pricing.Cost(c.usage)
+}
+
+func (c *client) Usage() Usage {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.usage
+}
+
+// GenerateJSON generates a response following the JSON schema of the options and decodes it into target.
+func GenerateJSON(ctx context.Context, client LLMClient, prompt string, options *LLMOptions, target any) error {
+	text, err := client.Generate(ctx, prompt, options)
+	if err != nil {
+		return err
+	}
+
+	text = strings.TrimSpace(text)
+
+	// Models without native structured output like to wrap the JSON into a code block
+	if start := strings.Index(text, "```"); start != -1 {
+		text = text[start+3:]
+		text = strings.TrimPrefix(text, "json")
+
+		if end := strings.LastIndex(text, "```"); end != -1 {
+			text = text[:end]
+		}
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), target); err != nil {
+		return fmt.Errorf("failed to decode structured response: %w", err)
+	}
+
+	return nil
+}
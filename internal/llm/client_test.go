@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLLMClientUnknownProvider(t *testing.T) {
+	_, err := NewLLMClient("unknown")
+
+	assert.ErrorContains(t, err, `invalid provider "unknown"`)
+}
+
+func TestNewLLMClientOpenAICompatibleRequiresBaseURL(t *testing.T) {
+	_, err := NewLLMClientFromConfig(Config{Provider: "openai-compatible"})
+
+	assert.ErrorContains(t, err, "requires a base URL")
+}
+
+func TestClientRetriesRateLimitedRequests(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "done"}}], "usage": {"prompt_tokens": 1000000, "completion_tokens": 500000}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewLLMClientFromConfig(Config{
+		Provider:   "openai-compatible",
+		BaseURL:    server.URL,
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+		Pricing:    Pricing{InputPerMillion: 1, OutputPerMillion: 4},
+	})
+	require.NoError(t, err)
+
+	text, err := client.Generate(t.Context(), "Hello", &LLMOptions{Model: "test"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "done", text)
+	assert.Equal(t, int32(3), requests.Load())
+
+	usage := client.Usage()
+	assert.Equal(t, 3, usage.Requests)
+	assert.Equal(t, 1000000, usage.InputTokens)
+	assert.Equal(t, 500000, usage.OutputTokens)
+	assert.InDelta(t, 3.0, usage.Cost, 0.0001)
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewLLMClientFromConfig(Config{Provider: "openai-compatible", BaseURL: server.URL, MaxRetries: 1, RetryDelay: time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = client.Generate(t.Context(), "Hello", &LLMOptions{Model: "test"})
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, 2, client.Usage().Requests)
+}
+
+func TestGenerateJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request ChatCompletionRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		require.NotNil(t, request.ResponseFormat)
+		assert.Equal(t, "json_schema", request.ResponseFormat.Type)
+		assert.JSONEq(t, `{"type": "object"}`, string(request.ResponseFormat.JSONSchema.Schema))
+
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "` + "```json\\n{\\\"valid\\\": true}\\n```" + `"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewLLMClientFromConfig(Config{Provider: "openai-compatible", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	var result struct {
+		Valid bool `json:"valid"`
+	}
+
+	require.NoError(t, GenerateJSON(t.Context(), client, "Is it valid?", &LLMOptions{ResponseSchema: json.RawMessage(`{"type": "object"}`)}, &result))
+	assert.True(t, result.Valid)
+}
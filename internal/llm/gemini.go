@@ -2,29 +2,36 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"net/http"
 	"strings"
-	"time"
 
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
-
-	"github.com/onlishop/onlishop-cli/logging"
 )
 
 type GeminiClient struct {
 	client *genai.Client
 }
 
-func newGeminiClient() (*GeminiClient, error) {
-	apiKey := os.Getenv("GEMINI_API_KEY")
+func newGeminiClient(config Config) (*GeminiClient, error) {
+	apiKey := config.APIKey
+	if apiKey == "" {
+		apiKey = firstEnv("GEMINI_API_KEY")
+	}
 
 	if apiKey == "" {
 		return nil, fmt.Errorf("GEMINI_API_KEY is not set")
 	}
 
-	client, err := genai.NewClient(context.Background(), option.WithAPIKey(apiKey))
+	clientOptions := []option.ClientOption{option.WithAPIKey(apiKey)}
+	if config.BaseURL != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(config.BaseURL))
+	}
+
+	client, err := genai.NewClient(context.Background(), clientOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -32,18 +39,94 @@ func newGeminiClient() (*GeminiClient, error) {
 	return &GeminiClient{client: client}, nil
 }
 
-func (c *GeminiClient) Generate(ctx context.Context, prompt string, options *LLMOptions) (string, error) {
-	resp, err := c.client.GenerativeModel(options.Model).GenerateContent(ctx, genai.Text(options.SystemPrompt+"\n\n"+prompt))
-	if err != nil {
-		if strings.Contains(err.Error(), "Resource has been exhausted") {
-			logging.FromContext(ctx).Warn("Resource exhausted, waiting 15 seconds before retrying")
-			time.Sleep(15 * time.Second)
+func (c *GeminiClient) complete(ctx context.Context, prompt string, options *LLMOptions, onDelta func(delta string)) (string, Usage, error) {
+	model := c.client.GenerativeModel(options.Model)
+
+	if len(options.ResponseSchema) > 0 {
+		model.ResponseMIMEType = "application/json"
+	}
+
+	if options.MaxTokens > 0 {
+		model.SetMaxOutputTokens(int32(options.MaxTokens))
+	}
+
+	input := genai.Text(schemaInstruction(options) + "\n\n" + prompt)
+
+	if onDelta == nil {
+		resp, err := model.GenerateContent(ctx, input)
+		if err != nil {
+			return "", Usage{}, geminiError(err)
+		}
+
+		text := geminiText(resp)
+		if text == "" {
+			return "", geminiUsage(resp), fmt.Errorf("no text content returned")
+		}
+
+		return text, geminiUsage(resp), nil
+	}
+
+	var text strings.Builder
+	var usage Usage
+
+	stream := model.GenerateContentStream(ctx, input)
+
+	for {
+		resp, err := stream.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
 
-			return c.Generate(ctx, prompt, options)
+		if err != nil {
+			return "", usage, geminiError(err)
 		}
 
-		return "", err
+		if resp.UsageMetadata != nil {
+			usage = geminiUsage(resp)
+		}
+
+		if delta := geminiText(resp); delta != "" {
+			text.WriteString(delta)
+			onDelta(delta)
+		}
+	}
+
+	return text.String(), usage, nil
+}
+
+// geminiError converts the exhausted quota error into a retryable status error.
+func geminiError(err error) error {
+	if strings.Contains(err.Error(), "Resource has been exhausted") {
+		return &StatusError{StatusCode: http.StatusTooManyRequests, Body: err.Error()}
+	}
+
+	return err
+}
+
+func geminiText(resp *genai.GenerateContentResponse) string {
+	var text strings.Builder
+
+	for _, candidate := range resp.Candidates {
+		if candidate.Content == nil {
+			continue
+		}
+
+		for _, part := range candidate.Content.Parts {
+			if t, ok := part.(genai.Text); ok {
+				text.WriteString(string(t))
+			}
+		}
+
+		break
+	}
+
+	return text.String()
+}
+
+func geminiUsage(resp *genai.GenerateContentResponse) Usage {
+	if resp.UsageMetadata == nil {
+		return Usage{}
 	}
 
-	return string(resp.Candidates[0].Content.Parts[0].(genai.Text)), nil
+	return Usage{InputTokens: int(resp.UsageMetadata.PromptTokenCount), OutputTokens: int(resp.UsageMetadata.CandidatesTokenCount)}
 }
@@ -1,27 +1,110 @@
 package llm
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRetries = 3
+	DefaultRetryDelay = 2 * time.Second
+)
+
+// Providers contains all supported provider names.
+var Providers = []string{"ollama", "openai", "openai-compatible", "anthropic", "gemini", "openrouter"}
 
 type LLMOptions struct {
 	Model        string
 	SystemPrompt string
+	// ResponseSchema is a JSON schema the response has to follow, providers without native support get it as instruction
+	ResponseSchema json.RawMessage
+	// MaxTokens limits the length of the response, providers requiring a limit use 8192 by default
+	MaxTokens int
 }
 
 type LLMClient interface {
 	Generate(ctx context.Context, prompt string, options *LLMOptions) (string, error)
+	// Stream works like Generate, but calls onDelta with each part of the response as soon as it arrives
+	Stream(ctx context.Context, prompt string, options *LLMOptions, onDelta func(delta string)) (string, error)
+	// Usage returns the tokens and costs of all requests made with this client
+	Usage() Usage
+}
+
+// Config configures the provider and the request handling of a client.
+type Config struct {
+	Provider string
+	// BaseURL overrides the API endpoint of the provider, e.g. a self-hosted gateway
+	BaseURL string
+	// APIKey overrides the API key of the provider specific environment variable
+	APIKey     string
+	MaxRetries int
+	RetryDelay time.Duration
+	Pricing    Pricing
+}
+
+// provider sends one request to an API, onDelta is nil when the response should not be streamed.
+type provider interface {
+	complete(ctx context.Context, prompt string, options *LLMOptions, onDelta func(delta string)) (string, Usage, error)
 }
 
 func NewLLMClient(provider string) (LLMClient, error) {
-	switch provider {
-	case "ollama":
-		return newOpenAIClient(), nil
-	case "openai":
-		return newOpenAIClient(), nil
+	return NewLLMClientFromConfig(Config{Provider: provider, MaxRetries: DefaultMaxRetries})
+}
+
+func NewLLMClientFromConfig(config Config) (LLMClient, error) {
+	var p provider
+	var err error
+
+	switch config.Provider {
+	case "ollama", "openai":
+		p = newOpenAIClient(config)
+	case "openai-compatible":
+		if config.BaseURL == "" {
+			return nil, fmt.Errorf("the openai-compatible provider requires a base URL")
+		}
+
+		p = newOpenAIClient(config)
+	case "anthropic":
+		p = newAnthropicClient(config)
 	case "gemini":
-		return newGeminiClient()
+		p, err = newGeminiClient(config)
 	case "openrouter":
-		return newOpenRouterClient()
+		p, err = newOpenRouterClient(config)
+	default:
+		return nil, fmt.Errorf("invalid provider %q, supported are %s", config.Provider, strings.Join(Providers, ", "))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if config.RetryDelay == 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+
+	return &client{provider: p, maxRetries: config.MaxRetries, retryDelay: config.RetryDelay, pricing: config.Pricing}, nil
+}
+
+// firstEnv returns the first non-empty value of the given environment variables.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// schemaInstruction returns the system prompt extended by the response schema for providers without native structured output.
+func schemaInstruction(options *LLMOptions) string {
+	if len(options.ResponseSchema) == 0 {
+		return options.SystemPrompt
 	}
 
-	panic("Invalid provider: " + provider)
+	return strings.TrimSpace(options.SystemPrompt + "\n\nRespond only with JSON matching this JSON schema, without any other text:\n" + string(options.ResponseSchema))
 }
@@ -7,17 +7,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/onlishop/onlishop-cli/logging"
 )
 
-// Client represents an OpenAI API client.
-type Client struct {
-	host   string
-	apiKey string
-	client *http.Client
+// OpenAIClient represents a client for the OpenAI API and compatible endpoints like Ollama or OpenRouter.
+type OpenAIClient struct {
+	host    string
+	apiKey  string
+	headers map[string]string
+	client  *http.Client
 }
 
 // ChatMessage represents a message in the chat completion request.
@@ -28,8 +29,33 @@ type ChatMessage struct {
 
 // ChatCompletionRequest represents the request body for chat completion.
 type ChatCompletionRequest struct {
-	Model    string        `json:"model"`
-	Messages []ChatMessage `json:"messages"`
+	Model          string              `json:"model"`
+	Messages       []ChatMessage       `json:"messages"`
+	MaxTokens      int                 `json:"max_tokens,omitempty"`
+	Stream         bool                `json:"stream,omitempty"`
+	StreamOptions  *ChatStreamOptions  `json:"stream_options,omitempty"`
+	ResponseFormat *ChatResponseFormat `json:"response_format,omitempty"`
+}
+
+type ChatStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type ChatResponseFormat struct {
+	Type       string          `json:"type"`
+	JSONSchema *ChatJSONSchema `json:"json_schema,omitempty"`
+}
+
+type ChatJSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict"`
+}
+
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
 }
 
 // ChatCompletionResponse represents the response from the chat completion endpoint.
@@ -43,29 +69,37 @@ type ChatCompletionResponse struct {
 		Message      ChatMessage `json:"message"`
 		FinishReason string      `json:"finish_reason"`
 	} `json:"choices"`
-	Usage struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
+	Usage chatUsage `json:"usage"`
+}
+
+// ChatCompletionChunk represents one streamed part of the chat completion.
+type ChatCompletionChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *chatUsage `json:"usage"`
 }
 
 // newOpenAIClient creates a new OpenAI client instance.
-func newOpenAIClient() *Client {
-	host := os.Getenv("OPENAI_API_HOST")
-	ollamaHost := os.Getenv("OLLAMA_HOST")
+func newOpenAIClient(config Config) *OpenAIClient {
+	host := config.BaseURL
 	if host == "" {
-		if ollamaHost != "" {
-			host = ollamaHost
-		} else {
-			host = "https://api.openai.com"
-		}
+		host = firstEnv("OPENAI_API_HOST", "OLLAMA_HOST")
+	}
+
+	if host == "" {
+		host = "https://api.openai.com"
 	}
 
-	apiKey := os.Getenv("OPENAI_API_KEY")
+	apiKey := config.APIKey
+	if apiKey == "" {
+		apiKey = firstEnv("OPENAI_API_KEY")
+	}
 
-	return &Client{
-		host:   host,
+	return &OpenAIClient{
+		host:   strings.TrimSuffix(host, "/"),
 		apiKey: apiKey,
 		client: &http.Client{
 			Timeout: 120 * time.Second,
@@ -73,8 +107,8 @@ func newOpenAIClient() *Client {
 	}
 }
 
-// Generate sends a chat completion request to the OpenAI API
-func (c *Client) Generate(ctx context.Context, prompt string, options *LLMOptions) (string, error) {
+// complete sends a chat completion request to the OpenAI API
+func (c *OpenAIClient) complete(ctx context.Context, prompt string, options *LLMOptions, onDelta func(delta string)) (string, Usage, error) {
 	messages := []ChatMessage{
 		{
 			Role:    "user",
@@ -91,18 +125,31 @@ func (c *Client) Generate(ctx context.Context, prompt string, options *LLMOption
 	}
 
 	reqBody := ChatCompletionRequest{
-		Model:    options.Model,
-		Messages: messages,
+		Model:     options.Model,
+		Messages:  messages,
+		MaxTokens: options.MaxTokens,
+	}
+
+	if len(options.ResponseSchema) > 0 {
+		reqBody.ResponseFormat = &ChatResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: &ChatJSONSchema{Name: "response", Schema: options.ResponseSchema, Strict: true},
+		}
+	}
+
+	if onDelta != nil {
+		reqBody.Stream = true
+		reqBody.StreamOptions = &ChatStreamOptions{IncludeUsage: true}
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request body: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/chat/completions", c.host), bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -110,32 +157,76 @@ func (c *Client) Generate(ctx context.Context, prompt string, options *LLMOption
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 	}
 
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
-			logging.FromContext(ctx).Warn("failed to close response body: %v", err)
+			logging.FromContext(ctx).Warnf("failed to close response body: %v", err)
 		}
 	}()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return "", fmt.Errorf("failed to read response body: %w", err)
+			return "", Usage{}, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return "", fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return "", Usage{}, newStatusError(resp, body)
+	}
+
+	if onDelta != nil {
+		return c.readStream(resp.Body, onDelta)
 	}
 
 	var response ChatCompletionResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	usage := Usage{InputTokens: response.Usage.PromptTokens, OutputTokens: response.Usage.CompletionTokens}
+
 	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no completion choices returned")
+		return "", usage, fmt.Errorf("no completion choices returned")
+	}
+
+	return response.Choices[0].Message.Content, usage, nil
+}
+
+func (c *OpenAIClient) readStream(body io.Reader, onDelta func(delta string)) (string, Usage, error) {
+	var text strings.Builder
+	var usage Usage
+
+	err := readServerSentEvents(body, func(data string) error {
+		var chunk ChatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to decode stream chunk: %w", err)
+		}
+
+		if chunk.Usage != nil {
+			usage = Usage{InputTokens: chunk.Usage.PromptTokens, OutputTokens: chunk.Usage.CompletionTokens}
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != "" {
+				text.WriteString(choice.Delta.Content)
+				onDelta(choice.Delta.Content)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", usage, err
+	}
+
+	if text.Len() == 0 {
+		return "", usage, fmt.Errorf("no completion choices returned")
 	}
 
-	return response.Choices[0].Message.Content, nil
+	return text.String(), usage, nil
 }
@@ -184,3 +184,37 @@ func TestOpenAIGenerateErrors(t *testing.T) {
 		assert.Contains(t, err.Error(), "no completion choices", "Error should mention no completion choices")
 	})
 }
+
+func TestOpenAIStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request ChatCompletionRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		assert.True(t, request.Stream)
+		require.NotNil(t, request.StreamOptions)
+		assert.True(t, request.StreamOptions.IncludeUsage)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"choices\": [{\"delta\": {\"content\": \"Hel\"}}]}\n\n"))
+		_, _ = w.Write([]byte("data: {\"choices\": [{\"delta\": {\"content\": \"lo\"}}]}\n\n"))
+		_, _ = w.Write([]byte("data: {\"choices\": [], \"usage\": {\"prompt_tokens\": 7, \"completion_tokens\": 2}}\n\n"))
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	t.Setenv("OPENAI_API_HOST", server.URL)
+
+	client, err := NewLLMClient("openai")
+	require.NoError(t, err)
+
+	var streamed string
+
+	text, err := client.Stream(t.Context(), "Hello", &LLMOptions{Model: "gpt-test"}, func(delta string) {
+		streamed += delta
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello", text)
+	assert.Equal(t, "Hello", streamed)
+	assert.Equal(t, Usage{Requests: 1, InputTokens: 7, OutputTokens: 2}, client.Usage())
+}
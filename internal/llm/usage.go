@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Usage counts the tokens of all requests of a run.
+type Usage struct {
+	Requests     int
+	InputTokens  int
+	OutputTokens int
+	// Cost in USD, zero when no pricing is configured
+	Cost float64
+}
+
+func (u Usage) String() string {
+	text := fmt.Sprintf("%d requests, %d input tokens, %d output tokens", u.Requests, u.InputTokens, u.OutputTokens)
+
+	if u.Cost > 0 {
+		text += fmt.Sprintf(", $%.4f", u.Cost)
+	}
+
+	return text
+}
+
+// Pricing contains the prices in USD per million tokens.
+type Pricing struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+func (p Pricing) Cost(usage Usage) float64 {
+	return (float64(usage.InputTokens)*p.InputPerMillion + float64(usage.OutputTokens)*p.OutputPerMillion) / 1_000_000
+}
+
+// readServerSentEvents calls onData with the data of each server sent event until the stream ends or sends [DONE].
+func readServerSentEvents(body io.Reader, onData func(data string) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		data = strings.TrimSpace(data)
+
+		if data == "[DONE]" {
+			return nil
+		}
+
+		if err := onData(data); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -7,8 +7,11 @@ import (
 )
 
 type ComposerLockPackage struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Type    string            `json:"type"`
+	License []string          `json:"license"`
+	Require map[string]string `json:"require,omitempty"`
 }
 
 type ComposerLock struct {
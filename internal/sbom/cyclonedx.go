@@ -0,0 +1,119 @@
+package sbom
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/onlishop/onlishop-cli/internal/spdx"
+)
+
+type cycloneDX struct {
+	BomFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Vendor string `json:"vendor"`
+	Name   string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	Type     string             `json:"type"`
+	BomRef   string             `json:"bom-ref,omitempty"`
+	Group    string             `json:"group,omitempty"`
+	Name     string             `json:"name"`
+	Version  string             `json:"version,omitempty"`
+	Purl     string             `json:"purl,omitempty"`
+	Licenses []cycloneDXLicense `json:"licenses,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License    *cycloneDXLicenseChoice `json:"license,omitempty"`
+	Expression string                  `json:"expression,omitempty"`
+}
+
+type cycloneDXLicenseChoice struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func newCycloneDX(document Document) cycloneDX {
+	licenses, _ := spdx.NewSpdxLicenses()
+
+	bom := cycloneDX{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + documentID(document),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: document.Timestamp.UTC().Format(time.RFC3339),
+			Tools:     []cycloneDXTool{{Vendor: "Onlishop", Name: "onlishop-cli"}},
+			Component: cycloneDXComponent{Type: "application", Name: document.Name, Version: document.Version},
+		},
+		Components: make([]cycloneDXComponent, 0, len(document.Components)),
+	}
+
+	for _, component := range document.Components {
+		entry := cycloneDXComponent{
+			Type:    "library",
+			BomRef:  component.PURL(),
+			Name:    component.Name,
+			Version: component.Version,
+			Purl:    component.PURL(),
+		}
+
+		if component.Ecosystem == EcosystemComposer {
+			if group, name, ok := strings.Cut(component.Name, "/"); ok {
+				entry.Group = group
+				entry.Name = name
+			}
+		}
+
+		for _, license := range component.Licenses {
+			entry.Licenses = append(entry.Licenses, newCycloneDXLicense(licenses, license))
+		}
+
+		bom.Components = append(bom.Components, entry)
+	}
+
+	return bom
+}
+
+func newCycloneDXLicense(licenses *spdx.SpdxLicenses, license string) cycloneDXLicense {
+	if licenses != nil {
+		if normalized, ok := licenses.Normalize(license); ok {
+			if strings.ContainsAny(normalized, " ()") {
+				return cycloneDXLicense{Expression: normalized}
+			}
+
+			return cycloneDXLicense{License: &cycloneDXLicenseChoice{ID: normalized}}
+		}
+	}
+
+	return cycloneDXLicense{License: &cycloneDXLicenseChoice{Name: license}}
+}
+
+// documentID derives a stable id from the content, so the same build produces the same SBOM.
+func documentID(document Document) string {
+	var content strings.Builder
+
+	content.WriteString(document.Name + "@" + document.Version)
+
+	for _, component := range document.Components {
+		content.WriteString("\n" + component.PURL())
+	}
+
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(content.String())).String()
+}
@@ -0,0 +1,230 @@
+// Package sbom generates software bills of materials of the composer and npm dependencies shipped with an extension or project.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/internal/packagist"
+)
+
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+
+	EcosystemComposer = "composer"
+	EcosystemNpm      = "npm"
+)
+
+// Formats contains all supported output formats.
+var Formats = []string{FormatCycloneDX, FormatSPDX}
+
+// Component is a third party package bundled into the build.
+type Component struct {
+	Ecosystem string
+	Name      string
+	Version   string
+	// Licenses are the declared licenses as given by the package manager
+	Licenses []string
+}
+
+// PURL returns the package url of the component.
+func (c Component) PURL() string {
+	name := c.Name
+
+	if c.Ecosystem == EcosystemNpm && strings.HasPrefix(name, "@") {
+		name = "%40" + strings.TrimPrefix(name, "@")
+	}
+
+	return fmt.Sprintf("pkg:%s/%s@%s", c.Ecosystem, name, url.PathEscape(c.Version))
+}
+
+// Document describes the bill of materials of one application.
+type Document struct {
+	Name       string
+	Version    string
+	Components []Component
+	Timestamp  time.Time
+}
+
+// Add adds the components to the document, ignoring duplicates.
+func (d *Document) Add(components ...Component) {
+	for _, component := range components {
+		duplicate := false
+
+		for _, existing := range d.Components {
+			if existing.PURL() == component.PURL() {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			d.Components = append(d.Components, component)
+		}
+	}
+
+	sort.Slice(d.Components, func(i, j int) bool {
+		return d.Components[i].PURL() < d.Components[j].PURL()
+	})
+}
+
+// FileName returns the conventional file name of the SBOM in the given format.
+func FileName(format string) string {
+	if format == FormatSPDX {
+		return "sbom.spdx.json"
+	}
+
+	return "sbom.cdx.json"
+}
+
+// Write encodes the document in the given format.
+func Write(w io.Writer, format string, document Document) error {
+	var content interface{}
+
+	switch format {
+	case FormatCycloneDX:
+		content = newCycloneDX(document)
+	case FormatSPDX:
+		content = newSPDX(document)
+	default:
+		return fmt.Errorf("unsupported SBOM format %q, supported are %s", format, strings.Join(Formats, ", "))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(content)
+}
+
+// WriteFile writes the document in the given format into the file.
+func WriteFile(file, format string, document Document) error {
+	handle, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("create sbom: %w", err)
+	}
+
+	if err := Write(handle, format, document); err != nil {
+		_ = handle.Close()
+		return err
+	}
+
+	return handle.Close()
+}
+
+// ReadComposerLock returns the packages installed by composer, excluding the dev packages.
+func ReadComposerLock(file string) ([]Component, error) {
+	lock, err := packagist.ReadComposerLock(file)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]Component, 0, len(lock.Packages))
+
+	for _, pkg := range lock.Packages {
+		// Meta packages do not contain any code
+		if pkg.Type == "metapackage" {
+			continue
+		}
+
+		components = append(components, Component{
+			Ecosystem: EcosystemComposer,
+			Name:      pkg.Name,
+			Version:   strings.TrimPrefix(pkg.Version, "v"),
+			Licenses:  pkg.License,
+		})
+	}
+
+	return components, nil
+}
+
+type packageLock struct {
+	LockfileVersion int                           `json:"lockfileVersion"`
+	Packages        map[string]packageLockPackage `json:"packages"`
+	Dependencies    map[string]packageLockPackage `json:"dependencies"`
+}
+
+type packageLockPackage struct {
+	Version     string          `json:"version"`
+	License     json.RawMessage `json:"license"`
+	Dev         bool            `json:"dev"`
+	DevOptional bool            `json:"devOptional"`
+	Link        bool            `json:"link"`
+}
+
+// licenses returns the license field, which is usually a string but can be an object in old packages.
+func (p packageLockPackage) licenses() []string {
+	var license string
+	if err := json.Unmarshal(p.License, &license); err == nil && license != "" {
+		return []string{license}
+	}
+
+	var typed struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(p.License, &typed); err == nil && typed.Type != "" {
+		return []string{typed.Type}
+	}
+
+	return nil
+}
+
+// ReadPackageLock returns the production dependencies of a package-lock.json.
+func ReadPackageLock(file string) ([]Component, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("could not parse package-lock.json: %w", err)
+	}
+
+	components := make([]Component, 0)
+
+	// lockfileVersion 2 and 3 list all installed packages by their node_modules path
+	for location, pkg := range lock.Packages {
+		if location == "" || pkg.Dev || pkg.DevOptional || pkg.Link || pkg.Version == "" {
+			continue
+		}
+
+		index := strings.LastIndex(location, "node_modules/")
+		if index == -1 {
+			continue
+		}
+
+		components = append(components, Component{
+			Ecosystem: EcosystemNpm,
+			Name:      location[index+len("node_modules/"):],
+			Version:   pkg.Version,
+			Licenses:  pkg.licenses(),
+		})
+	}
+
+	if len(lock.Packages) == 0 {
+		for name, pkg := range lock.Dependencies {
+			if pkg.Dev {
+				continue
+			}
+
+			components = append(components, Component{
+				Ecosystem: EcosystemNpm,
+				Name:      name,
+				Version:   pkg.Version,
+			})
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	return components, nil
+}
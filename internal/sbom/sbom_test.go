@@ -0,0 +1,125 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+
+	return file
+}
+
+func TestReadComposerLock(t *testing.T) {
+	file := writeTestFile(t, "composer.lock", `{"packages": [
+		{"name": "symfony/polyfill-php83", "version": "v1.31.0", "type": "library", "license": ["MIT"]},
+		{"name": "acme/meta", "version": "1.0.0", "type": "metapackage"}
+	], "packages-dev": [{"name": "phpunit/phpunit", "version": "10.0.0"}]}`)
+
+	components, err := ReadComposerLock(file)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Component{{Ecosystem: EcosystemComposer, Name: "symfony/polyfill-php83", Version: "1.31.0", Licenses: []string{"MIT"}}}, components)
+}
+
+func TestReadPackageLock(t *testing.T) {
+	file := writeTestFile(t, "package-lock.json", `{"lockfileVersion": 3, "packages": {
+		"": {"name": "extension"},
+		"node_modules/@floating-ui/dom": {"version": "1.6.0", "license": "MIT"},
+		"node_modules/a/node_modules/b": {"version": "2.0.0", "license": {"type": "ISC"}},
+		"node_modules/eslint": {"version": "9.0.0", "dev": true, "license": "MIT"}
+	}}`)
+
+	components, err := ReadPackageLock(file)
+	require.NoError(t, err)
+
+	document := Document{}
+	document.Add(components...)
+
+	assert.Equal(t, []Component{
+		{Ecosystem: EcosystemNpm, Name: "@floating-ui/dom", Version: "1.6.0", Licenses: []string{"MIT"}},
+		{Ecosystem: EcosystemNpm, Name: "b", Version: "2.0.0", Licenses: []string{"ISC"}},
+	}, document.Components)
+}
+
+func TestReadPackageLockV1(t *testing.T) {
+	file := writeTestFile(t, "package-lock.json", `{"lockfileVersion": 1, "dependencies": {
+		"lodash": {"version": "4.17.21"},
+		"jest": {"version": "29.0.0", "dev": true}
+	}}`)
+
+	components, err := ReadPackageLock(file)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Component{{Ecosystem: EcosystemNpm, Name: "lodash", Version: "4.17.21"}}, components)
+}
+
+func TestPURL(t *testing.T) {
+	assert.Equal(t, "pkg:composer/symfony/console@7.0.0", Component{Ecosystem: EcosystemComposer, Name: "symfony/console", Version: "7.0.0"}.PURL())
+	assert.Equal(t, "pkg:npm/%40floating-ui/dom@1.6.0", Component{Ecosystem: EcosystemNpm, Name: "@floating-ui/dom", Version: "1.6.0"}.PURL())
+}
+
+func testDocument() Document {
+	document := Document{Name: "SwagExample", Version: "1.0.0", Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	document.Add(
+		Component{Ecosystem: EcosystemComposer, Name: "symfony/console", Version: "7.0.0", Licenses: []string{"mit"}},
+		Component{Ecosystem: EcosystemComposer, Name: "acme/dual", Version: "1.0.0", Licenses: []string{"MIT", "GPL-3.0-or-later"}},
+		Component{Ecosystem: EcosystemNpm, Name: "internal", Version: "1.0.0", Licenses: []string{"proprietary"}},
+	)
+
+	return document
+}
+
+func TestWriteCycloneDX(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, FormatCycloneDX, testDocument()))
+
+	var bom cycloneDX
+	require.NoError(t, json.Unmarshal(out.Bytes(), &bom))
+
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	assert.Equal(t, "2024-01-01T00:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "SwagExample", bom.Metadata.Component.Name)
+	assert.Len(t, bom.Components, 3)
+
+	assert.Equal(t, "acme", bom.Components[0].Group)
+	assert.Equal(t, "dual", bom.Components[0].Name)
+	assert.Equal(t, "MIT", bom.Components[1].Licenses[0].License.ID)
+	assert.Equal(t, "proprietary", bom.Components[2].Licenses[0].License.Name)
+
+	var second bytes.Buffer
+	require.NoError(t, Write(&second, FormatCycloneDX, testDocument()))
+	assert.Equal(t, out.String(), second.String())
+}
+
+func TestWriteSPDX(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, FormatSPDX, testDocument()))
+
+	var doc spdxDocument
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+
+	assert.Equal(t, "SPDX-2.3", doc.SpdxVersion)
+	assert.Len(t, doc.Packages, 4)
+	assert.Len(t, doc.Relationships, 4)
+
+	assert.Equal(t, "MIT OR GPL-3.0-or-later", doc.Packages[1].LicenseDeclared)
+	assert.Equal(t, "MIT", doc.Packages[2].LicenseDeclared)
+	assert.Equal(t, "LicenseRef-proprietary", doc.Packages[3].LicenseDeclared)
+	assert.Equal(t, "SPDXRef-Package-composer-acme-dual-1.0.0", doc.Packages[1].SPDXID)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	assert.ErrorContains(t, Write(&bytes.Buffer{}, "xml", testDocument()), "unsupported SBOM format")
+}
@@ -0,0 +1,127 @@
+package sbom
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/onlishop/onlishop-cli/internal/spdx"
+)
+
+var spdxIDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+type spdxDocument struct {
+	SpdxVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+func newSPDX(document Document) spdxDocument {
+	licenses, _ := spdx.NewSpdxLicenses()
+
+	rootID := "SPDXRef-" + spdxIDInvalidChars.ReplaceAllString(document.Name, "-")
+
+	doc := spdxDocument{
+		SpdxVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              document.Name,
+		DocumentNamespace: "https://onlishop.com/spdxdocs/" + spdxIDInvalidChars.ReplaceAllString(document.Name, "-") + "-" + documentID(document),
+		CreationInfo: spdxCreationInfo{
+			Created:  document.Timestamp.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: onlishop-cli"},
+		},
+		Packages: []spdxPackage{{
+			Name:             document.Name,
+			SPDXID:           rootID,
+			VersionInfo:      document.Version,
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  "NOASSERTION",
+		}},
+		Relationships: []spdxRelationship{{SpdxElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: rootID}},
+	}
+
+	for _, component := range document.Components {
+		id := "SPDXRef-Package-" + component.Ecosystem + "-" + spdxIDInvalidChars.ReplaceAllString(component.Name+"-"+component.Version, "-")
+
+		doc.Packages = append(doc.Packages, spdxPackage{
+			Name:             component.Name,
+			SPDXID:           id,
+			VersionInfo:      component.Version,
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  spdxLicenseExpression(licenses, component.Licenses),
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  component.PURL(),
+			}},
+		})
+
+		doc.Relationships = append(doc.Relationships, spdxRelationship{SpdxElementID: rootID, RelationshipType: "DEPENDS_ON", RelatedSpdxElement: id})
+	}
+
+	return doc
+}
+
+// spdxLicenseExpression combines the declared licenses like composer does, multiple licenses are a choice.
+func spdxLicenseExpression(licenses *spdx.SpdxLicenses, declared []string) string {
+	if len(declared) == 0 {
+		return "NOASSERTION"
+	}
+
+	expressions := make([]string, 0, len(declared))
+
+	for _, license := range declared {
+		normalized, ok := "", false
+		if licenses != nil {
+			normalized, ok = licenses.Normalize(license)
+		}
+
+		if !ok {
+			normalized = "LicenseRef-" + strings.Trim(spdxIDInvalidChars.ReplaceAllString(license, "-"), "-")
+		}
+
+		if len(declared) > 1 && strings.Contains(normalized, " ") {
+			normalized = "(" + normalized + ")"
+		}
+
+		expressions = append(expressions, normalized)
+	}
+
+	return strings.Join(expressions, " OR ")
+}
@@ -0,0 +1,258 @@
+// Package signing creates and verifies ed25519 signatures in the minisign file format.
+//
+// Public keys and signatures are compatible with minisign, so a signature can also be verified with
+// "minisign -V -p extension.pub -m extension.zip". Secret keys are stored unencrypted, as they are usually
+// passed as CI secret.
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	untrustedCommentPrefix = "untrusted comment: "
+	trustedCommentPrefix   = "trusted comment: "
+	keyIDLength            = 8
+)
+
+var algorithmEd25519 = []byte("Ed")
+
+// PublicKey is a minisign compatible ed25519 public key.
+type PublicKey struct {
+	ID  [keyIDLength]byte
+	Key ed25519.PublicKey
+}
+
+// SecretKey is an unencrypted ed25519 secret key.
+type SecretKey struct {
+	ID  [keyIDLength]byte
+	Key ed25519.PrivateKey
+}
+
+// Signature is a minisign compatible signature of a file.
+type Signature struct {
+	UntrustedComment string
+	KeyID            [keyIDLength]byte
+	Signature        []byte
+	TrustedComment   string
+	GlobalSignature  []byte
+}
+
+func GenerateKey() (*PublicKey, *SecretKey, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var id [keyIDLength]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, nil, err
+	}
+
+	return &PublicKey{ID: id, Key: public}, &SecretKey{ID: id, Key: private}, nil
+}
+
+// KeyID returns the key id as shown by minisign.
+func (k PublicKey) KeyID() string {
+	return formatKeyID(k.ID)
+}
+
+func (k PublicKey) String() string {
+	return encodeFile("minisign public key "+k.KeyID(), algorithmEd25519, k.ID[:], k.Key)
+}
+
+func (k SecretKey) Public() *PublicKey {
+	return &PublicKey{ID: k.ID, Key: k.Key.Public().(ed25519.PublicKey)}
+}
+
+func (k SecretKey) String() string {
+	return encodeFile("onlishop-cli secret key "+formatKeyID(k.ID), algorithmEd25519, k.ID[:], k.Key)
+}
+
+// ParsePublicKey parses a public key file or only its base64 encoded line.
+func ParsePublicKey(content string) (*PublicKey, error) {
+	data, err := decodeKey(content, ed25519.PublicKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	key := &PublicKey{Key: ed25519.PublicKey(data[2+keyIDLength:])}
+	copy(key.ID[:], data[2:])
+
+	return key, nil
+}
+
+// ParseSecretKey parses a secret key file or only its base64 encoded line.
+func ParseSecretKey(content string) (*SecretKey, error) {
+	data, err := decodeKey(content, ed25519.PrivateKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret key: %w", err)
+	}
+
+	key := &SecretKey{Key: ed25519.PrivateKey(data[2+keyIDLength:])}
+	copy(key.ID[:], data[2:])
+
+	return key, nil
+}
+
+func ReadPublicKeyFile(file string) (*PublicKey, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read public key: %w", err)
+	}
+
+	return ParsePublicKey(string(content))
+}
+
+func ReadSecretKeyFile(file string) (*SecretKey, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read secret key: %w", err)
+	}
+
+	return ParseSecretKey(string(content))
+}
+
+// Sign signs the message, the trusted comment is covered by the signature as well.
+func Sign(key *SecretKey, message []byte, trustedComment string) *Signature {
+	signature := ed25519.Sign(key.Key, message)
+
+	return &Signature{
+		UntrustedComment: "signature from onlishop-cli secret key " + formatKeyID(key.ID),
+		KeyID:            key.ID,
+		Signature:        signature,
+		TrustedComment:   trustedComment,
+		GlobalSignature:  ed25519.Sign(key.Key, append(append([]byte{}, signature...), []byte(trustedComment)...)),
+	}
+}
+
+func SignFile(key *SecretKey, file, trustedComment string) (*Signature, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read file to sign: %w", err)
+	}
+
+	return Sign(key, content, trustedComment), nil
+}
+
+// Verify checks that the message and the trusted comment have been signed by the given key.
+func Verify(key *PublicKey, message []byte, signature *Signature) error {
+	if key.ID != signature.KeyID {
+		return fmt.Errorf("signature was created with key %s, expected key %s", formatKeyID(signature.KeyID), key.KeyID())
+	}
+
+	if !ed25519.Verify(key.Key, message, signature.Signature) {
+		return errors.New("signature does not match the content")
+	}
+
+	if !ed25519.Verify(key.Key, append(append([]byte{}, signature.Signature...), []byte(signature.TrustedComment)...), signature.GlobalSignature) {
+		return errors.New("trusted comment does not match the signature")
+	}
+
+	return nil
+}
+
+func VerifyFile(key *PublicKey, file, signatureFile string) (*Signature, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read signed file: %w", err)
+	}
+
+	signatureContent, err := os.ReadFile(signatureFile)
+	if err != nil {
+		return nil, fmt.Errorf("read signature: %w", err)
+	}
+
+	signature, err := ParseSignature(string(signatureContent))
+	if err != nil {
+		return nil, err
+	}
+
+	return signature, Verify(key, content, signature)
+}
+
+func (s Signature) String() string {
+	var out strings.Builder
+
+	out.WriteString(untrustedCommentPrefix + s.UntrustedComment + "\n")
+	out.WriteString(base64.StdEncoding.EncodeToString(append(append(append([]byte{}, algorithmEd25519...), s.KeyID[:]...), s.Signature...)) + "\n")
+	out.WriteString(trustedCommentPrefix + s.TrustedComment + "\n")
+	out.WriteString(base64.StdEncoding.EncodeToString(s.GlobalSignature) + "\n")
+
+	return out.String()
+}
+
+func ParseSignature(content string) (*Signature, error) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n")), "\n")
+
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], untrustedCommentPrefix) || !strings.HasPrefix(lines[2], trustedCommentPrefix) {
+		return nil, errors.New("invalid signature: expected minisign signature format")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if len(data) != 2+keyIDLength+ed25519.SignatureSize || !bytes.Equal(data[:2], algorithmEd25519) {
+		return nil, errors.New("invalid signature: unsupported algorithm, prehashed signatures are not supported")
+	}
+
+	globalSignature, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSignature) != ed25519.SignatureSize {
+		return nil, errors.New("invalid signature: invalid global signature")
+	}
+
+	signature := &Signature{
+		UntrustedComment: strings.TrimPrefix(lines[0], untrustedCommentPrefix),
+		Signature:        data[2+keyIDLength:],
+		TrustedComment:   strings.TrimPrefix(lines[2], trustedCommentPrefix),
+		GlobalSignature:  globalSignature,
+	}
+	copy(signature.KeyID[:], data[2:])
+
+	return signature, nil
+}
+
+func encodeFile(comment string, parts ...[]byte) string {
+	return untrustedCommentPrefix + comment + "\n" + base64.StdEncoding.EncodeToString(bytes.Join(parts, nil)) + "\n"
+}
+
+// decodeKey accepts a whole key file or only the base64 line and validates the algorithm and size.
+func decodeKey(content string, keySize int) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n")), "\n")
+	encoded := strings.TrimSpace(lines[len(lines)-1])
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 2+keyIDLength+keySize {
+		return nil, fmt.Errorf("expected %d bytes, got %d", 2+keyIDLength+keySize, len(data))
+	}
+
+	if !bytes.Equal(data[:2], algorithmEd25519) {
+		return nil, fmt.Errorf("unsupported algorithm %q", data[:2])
+	}
+
+	return data, nil
+}
+
+// formatKeyID formats the key id like minisign, which prints the little endian id as hex.
+func formatKeyID(id [keyIDLength]byte) string {
+	reversed := make([]byte, keyIDLength)
+	for i := range id {
+		reversed[keyIDLength-1-i] = id[i]
+	}
+
+	return strings.ToUpper(hex.EncodeToString(reversed))
+}
@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	public, secret, err := GenerateKey()
+	require.NoError(t, err)
+
+	signature := Sign(secret, []byte("content"), "file:extension.zip")
+
+	assert.NoError(t, Verify(public, []byte("content"), signature))
+	assert.ErrorContains(t, Verify(public, []byte("modified"), signature), "does not match the content")
+
+	signature.TrustedComment = "file:other.zip"
+	assert.ErrorContains(t, Verify(public, []byte("content"), signature), "trusted comment")
+}
+
+func TestVerifyWithOtherKey(t *testing.T) {
+	_, secret, err := GenerateKey()
+	require.NoError(t, err)
+
+	otherPublic, _, err := GenerateKey()
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, Verify(otherPublic, []byte("content"), Sign(secret, []byte("content"), "")), "expected key")
+}
+
+func TestKeysAndSignatureRoundTrip(t *testing.T) {
+	public, secret, err := GenerateKey()
+	require.NoError(t, err)
+
+	parsedPublic, err := ParsePublicKey(public.String())
+	require.NoError(t, err)
+	assert.Equal(t, public, parsedPublic)
+
+	parsedSecret, err := ParseSecretKey(secret.String())
+	require.NoError(t, err)
+	assert.Equal(t, secret, parsedSecret)
+	assert.Equal(t, public, parsedSecret.Public())
+
+	signature := Sign(secret, []byte("content"), "timestamp:1")
+
+	parsedSignature, err := ParseSignature(signature.String())
+	require.NoError(t, err)
+	assert.Equal(t, signature, parsedSignature)
+}
+
+func TestParsePublicKeyOfMinisign(t *testing.T) {
+	key, err := ParsePublicKey("untrusted comment: minisign public key 3D2BA3B5A0DBE5C2\nRWTC5dugtaMrPS4HHa5dUdd21kfeG7A+BukZQK2Tvzz3HzkZ+eo9JuFN\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, "3D2BA3B5A0DBE5C2", key.KeyID())
+}
+
+func TestParseInvalidKey(t *testing.T) {
+	_, err := ParsePublicKey("not a key")
+	assert.Error(t, err)
+
+	public, _, err := GenerateKey()
+	require.NoError(t, err)
+
+	_, err = ParseSecretKey(public.String())
+	assert.ErrorContains(t, err, "expected")
+}
+
+func TestSignAndVerifyFile(t *testing.T) {
+	public, secret, err := GenerateKey()
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "extension.zip")
+	require.NoError(t, os.WriteFile(file, []byte("zip"), 0o644))
+
+	signature, err := SignFile(secret, file, "file:extension.zip")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file+".minisig", []byte(signature.String()), 0o644))
+
+	verified, err := VerifyFile(public, file, file+".minisig")
+	assert.NoError(t, err)
+	assert.Equal(t, "file:extension.zip", verified.TrustedComment)
+}
@@ -0,0 +1,54 @@
+package spdx
+
+import "strings"
+
+// strongCopyleftPrefixes are licenses which require the combined work to be distributed under the same license.
+var strongCopyleftPrefixes = []string{"GPL-", "AGPL-", "SSPL-", "OSL-", "EUPL-"}
+
+// IsStrongCopyleft reports whether the license requires the whole combined work to use the same license.
+func IsStrongCopyleft(license string) bool {
+	for _, prefix := range strongCopyleftPrefixes {
+		if strings.HasPrefix(strings.ToUpper(license), prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsCompatible reports whether a dependency under the given license can be bundled into a work under the target license.
+// This is a conservative approximation for the common cases and no legal advice: permissive and weak copyleft
+// dependencies can be bundled into everything, strong copyleft dependencies only into strong copyleft works.
+func IsCompatible(dependency, target string) bool {
+	if !IsStrongCopyleft(dependency) {
+		return true
+	}
+
+	if !IsStrongCopyleft(target) {
+		return false
+	}
+
+	// GPL-2.0-only cannot be combined with any version 3 license
+	if isGPLv2Only(target) && strings.Contains(dependency, "-3.0") {
+		return false
+	}
+
+	if isGPLv2Only(dependency) && strings.Contains(target, "-3.0") {
+		return false
+	}
+
+	return true
+}
+
+func isGPLv2Only(license string) bool {
+	return license == "GPL-2.0-only" || license == "GPL-2.0"
+}
+
+// Matches reports whether the license matches the pattern, which can end with * to match a prefix like GPL-*.
+func Matches(license, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(strings.ToLower(license), strings.ToLower(prefix))
+	}
+
+	return strings.EqualFold(strings.TrimSuffix(license, "+"), strings.TrimSuffix(pattern, "+"))
+}
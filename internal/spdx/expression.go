@@ -0,0 +1,98 @@
+package spdx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Choices resolves a SPDX expression into the alternatives a licensee can choose from.
+// Each alternative contains the license identifiers which apply together, "MIT OR (Apache-2.0 AND BSD-3-Clause)" becomes [[MIT] [Apache-2.0 BSD-3-Clause]].
+// License exceptions are dropped, as they only grant additional permissions.
+func (s *SpdxLicenses) Choices(expression string) ([][]string, error) {
+	if valid, err := s.isValidLicenseString(expression); !valid {
+		if err == nil {
+			err = fmt.Errorf("invalid license expression %q", expression)
+		}
+
+		return nil, err
+	}
+
+	parser := &expressionParser{tokens: tokenize(expression), licenses: s}
+
+	choices := parser.parseOr()
+
+	return choices, nil
+}
+
+type expressionParser struct {
+	tokens   []string
+	position int
+	licenses *SpdxLicenses
+}
+
+func (p *expressionParser) peek() string {
+	if p.position >= len(p.tokens) {
+		return ""
+	}
+
+	return strings.ToLower(p.tokens[p.position])
+}
+
+func (p *expressionParser) parseOr() [][]string {
+	choices := p.parseAnd()
+
+	for p.peek() == "or" {
+		p.position++
+		choices = append(choices, p.parseAnd()...)
+	}
+
+	return choices
+}
+
+func (p *expressionParser) parseAnd() [][]string {
+	choices := p.parseFactor()
+
+	for p.peek() == "and" {
+		p.position++
+
+		right := p.parseFactor()
+		combined := make([][]string, 0, len(choices)*len(right))
+
+		for _, left := range choices {
+			for _, other := range right {
+				combined = append(combined, append(append([]string{}, left...), other...))
+			}
+		}
+
+		choices = combined
+	}
+
+	return choices
+}
+
+func (p *expressionParser) parseFactor() [][]string {
+	if p.peek() == "(" {
+		p.position++
+		choices := p.parseOr()
+		p.position++ // closing parenthesis
+
+		return choices
+	}
+
+	license := strings.Trim(p.tokens[p.position], `"`)
+	p.position++
+
+	if p.peek() == "with" {
+		p.position += 2
+	}
+
+	if entry, ok := p.licenses.licenses[strings.ToLower(strings.TrimSuffix(license, "+"))]; ok {
+		if strings.HasSuffix(license, "+") {
+			license = entry[0].(string) + "+"
+		} else {
+			license = entry[0].(string)
+		}
+	}
+
+	return [][]string{{license}}
+}
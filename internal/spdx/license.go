@@ -285,3 +285,18 @@ func tokenize(input string) []string {
 	}
 	return tokens
 }
+
+// Normalize returns the license with the canonical casing of a single SPDX identifier, or the expression itself when it is valid.
+func (s *SpdxLicenses) Normalize(license string) (string, bool) {
+	license = strings.TrimSpace(license)
+
+	if entry, ok := s.licenses[strings.ToLower(license)]; ok {
+		return entry[0].(string), true
+	}
+
+	if valid, _ := s.isValidLicenseString(license); valid {
+		return license, true
+	}
+
+	return "", false
+}
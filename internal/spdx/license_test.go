@@ -33,3 +33,62 @@ func TestSpdxLicenses_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestSpdxLicenses_Normalize(t *testing.T) {
+	s, _ := NewSpdxLicenses()
+
+	license, ok := s.Normalize("mit")
+	assert.True(t, ok)
+	assert.Equal(t, "MIT", license)
+
+	license, ok = s.Normalize("(MIT or GPL-3.0-or-later)")
+	assert.True(t, ok)
+	assert.Equal(t, "(MIT or GPL-3.0-or-later)", license)
+
+	_, ok = s.Normalize("proprietary")
+	assert.False(t, ok)
+}
+
+func TestSpdxLicenses_Choices(t *testing.T) {
+	s, _ := NewSpdxLicenses()
+
+	tests := []struct {
+		expression string
+		choices    [][]string
+	}{
+		{"mit", [][]string{{"MIT"}}},
+		{"MIT OR Apache-2.0", [][]string{{"MIT"}, {"Apache-2.0"}}},
+		{"MIT AND (Apache-2.0 OR BSD-3-Clause)", [][]string{{"MIT", "Apache-2.0"}, {"MIT", "BSD-3-Clause"}}},
+		{"GPL-2.0-or-later WITH Classpath-exception-2.0 OR MIT", [][]string{{"GPL-2.0-or-later"}, {"MIT"}}},
+		{"GPL-2.0+", [][]string{{"GPL-2.0+"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			choices, err := s.Choices(tt.expression)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.choices, choices)
+		})
+	}
+
+	_, err := s.Choices("proprietary")
+	assert.Error(t, err)
+}
+
+func TestIsCompatible(t *testing.T) {
+	assert.True(t, IsCompatible("MIT", "proprietary"))
+	assert.True(t, IsCompatible("LGPL-3.0-or-later", "proprietary"))
+	assert.False(t, IsCompatible("GPL-3.0-or-later", "proprietary"))
+	assert.False(t, IsCompatible("AGPL-3.0-only", "MIT"))
+	assert.True(t, IsCompatible("GPL-3.0-only", "GPL-3.0-or-later"))
+	assert.False(t, IsCompatible("GPL-3.0-only", "GPL-2.0-only"))
+	assert.True(t, IsCompatible("MIT", "GPL-2.0-only"))
+}
+
+func TestMatches(t *testing.T) {
+	assert.True(t, Matches("GPL-3.0-only", "gpl-*"))
+	assert.True(t, Matches("AGPL-3.0-only", "AGPL-3.0-only"))
+	assert.False(t, Matches("LGPL-3.0-only", "GPL-*"))
+	assert.False(t, Matches("MIT", "MIT-0"))
+}
@@ -0,0 +1,136 @@
+package system
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileWatcher detects changes of files by polling their modification times, so it works on all platforms and file systems.
+type FileWatcher struct {
+	// Dirs are walked recursively, missing directories are ignored
+	Dirs []string
+	// Suffixes limits the watched files, like .scss or .html.twig
+	Suffixes []string
+	Interval time.Duration
+
+	files map[string]time.Time
+}
+
+func NewFileWatcher(dirs []string, suffixes ...string) *FileWatcher {
+	return &FileWatcher{
+		Dirs:     dirs,
+		Suffixes: suffixes,
+		Interval: 500 * time.Millisecond,
+	}
+}
+
+// Scan returns all files which have been added, modified or removed since the last scan.
+// The first scan only records the current state and reports nothing.
+func (w *FileWatcher) Scan() []string {
+	current := make(map[string]time.Time)
+
+	for _, dir := range w.Dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if d.IsDir() {
+				if path != dir && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !w.matches(path) {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+
+			current[path] = info.ModTime()
+
+			return nil
+		})
+	}
+
+	if w.files == nil {
+		w.files = current
+		return nil
+	}
+
+	changed := make([]string, 0)
+
+	for path, modTime := range current {
+		if previous, ok := w.files[path]; !ok || !previous.Equal(modTime) {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range w.files {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	w.files = current
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+// Watch scans the directories until the context is canceled and calls onChange with the changed files.
+func (w *FileWatcher) Watch(ctx context.Context, onChange func(files []string)) {
+	w.Scan()
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if changed := w.Scan(); len(changed) > 0 {
+				onChange(changed)
+			}
+		}
+	}
+}
+
+func (w *FileWatcher) matches(path string) bool {
+	if len(w.Suffixes) == 0 {
+		return true
+	}
+
+	for _, suffix := range w.Suffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExistingDirs filters the given paths to the ones which exist as directory.
+func ExistingDirs(paths ...string) []string {
+	dirs := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+			dirs = append(dirs, path)
+		}
+	}
+
+	return dirs
+}
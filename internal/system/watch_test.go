@@ -0,0 +1,46 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWatcherScan(t *testing.T) {
+	dir := t.TempDir()
+
+	scssFile := filepath.Join(dir, "base.scss")
+	assert.NoError(t, os.WriteFile(scssFile, []byte(".a {}"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.js"), []byte(""), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules"), 0o755))
+
+	watcher := NewFileWatcher([]string{dir, filepath.Join(dir, "missing")}, ".scss")
+
+	assert.Empty(t, watcher.Scan())
+	assert.Empty(t, watcher.Scan())
+
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(scssFile, future, future))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.js"), []byte("changed"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "ignored.scss"), []byte(""), 0o644))
+
+	assert.Equal(t, []string{scssFile}, watcher.Scan())
+
+	newFile := filepath.Join(dir, "_new.scss")
+	assert.NoError(t, os.WriteFile(newFile, []byte(""), 0o644))
+	assert.NoError(t, os.Remove(scssFile))
+
+	assert.Equal(t, []string{newFile, scssFile}, watcher.Scan())
+}
+
+func TestExistingDirs(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+
+	assert.NoError(t, os.WriteFile(file, []byte(""), 0o644))
+
+	assert.Equal(t, []string{dir}, ExistingDirs(dir, file, filepath.Join(dir, "missing")))
+}
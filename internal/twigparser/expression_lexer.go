@@ -0,0 +1,298 @@
+package twigparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenName
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenPunctuation
+	tokenArrow
+)
+
+type token struct {
+	Type  tokenType
+	Value string
+	Pos   int
+	// Quote is the quote character of a string token, interpolation is only supported in double quoted strings
+	Quote byte
+}
+
+type operator struct {
+	precedence       int
+	rightAssociative bool
+}
+
+// binaryOperators contains the binary operators of Twig with their precedence.
+var binaryOperators = map[string]operator{
+	"or":          {precedence: 10},
+	"xor":         {precedence: 12},
+	"and":         {precedence: 15},
+	"b-or":        {precedence: 16},
+	"b-xor":       {precedence: 17},
+	"b-and":       {precedence: 18},
+	"==":          {precedence: 20},
+	"!=":          {precedence: 20},
+	"<=>":         {precedence: 20},
+	"<":           {precedence: 20},
+	">":           {precedence: 20},
+	">=":          {precedence: 20},
+	"<=":          {precedence: 20},
+	"not in":      {precedence: 20},
+	"in":          {precedence: 20},
+	"matches":     {precedence: 20},
+	"starts with": {precedence: 20},
+	"ends with":   {precedence: 20},
+	"has some":    {precedence: 20},
+	"has every":   {precedence: 20},
+	"..":          {precedence: 25},
+	"+":           {precedence: 30},
+	"-":           {precedence: 30},
+	"~":           {precedence: 40},
+	"*":           {precedence: 60},
+	"/":           {precedence: 60},
+	"//":          {precedence: 60},
+	"%":           {precedence: 60},
+	"is":          {precedence: 100},
+	"is not":      {precedence: 100},
+	"**":          {precedence: 200, rightAssociative: true},
+	"??":          {precedence: 300, rightAssociative: true},
+}
+
+// unaryOperators contains the unary operators of Twig with their precedence.
+var unaryOperators = map[string]int{
+	"not": 50,
+	"-":   500,
+	"+":   500,
+}
+
+// lexerOperators contains all operators, the longest first so "not in" wins over "not".
+var lexerOperators = func() []string {
+	operators := make([]string, 0, len(binaryOperators)+len(unaryOperators))
+	for op := range binaryOperators {
+		operators = append(operators, op)
+	}
+	for op := range unaryOperators {
+		if _, ok := binaryOperators[op]; !ok {
+			operators = append(operators, op)
+		}
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		if len(operators[i]) != len(operators[j]) {
+			return len(operators[i]) > len(operators[j])
+		}
+		return operators[i] < operators[j]
+	})
+	return operators
+}()
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x7f
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// tokenizeExpression splits the content of a {{ }} or {% %} tag into tokens.
+func tokenizeExpression(input string) ([]token, error) {
+	var tokens []token
+	pos := 0
+
+	for {
+		for pos < len(input) && isWhitespace(rune(input[pos])) {
+			pos++
+		}
+
+		if pos >= len(input) {
+			break
+		}
+
+		c := input[pos]
+
+		if c == '\'' || c == '"' {
+			end, err := scanString(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{Type: tokenString, Value: input[pos+1 : end-1], Pos: pos, Quote: c})
+			pos = end
+			continue
+		}
+
+		if isDigit(c) {
+			end := scanNumber(input, pos)
+			tokens = append(tokens, token{Type: tokenNumber, Value: input[pos:end], Pos: pos})
+			pos = end
+			continue
+		}
+
+		if op, end := matchOperator(input, pos); op != "" {
+			tokens = append(tokens, token{Type: tokenOperator, Value: op, Pos: pos})
+			pos = end
+			continue
+		}
+
+		if isNameStart(c) {
+			end := pos + 1
+			for end < len(input) && isNameChar(input[end]) {
+				end++
+			}
+			tokens = append(tokens, token{Type: tokenName, Value: input[pos:end], Pos: pos})
+			pos = end
+			continue
+		}
+
+		if strings.HasPrefix(input[pos:], "=>") {
+			tokens = append(tokens, token{Type: tokenArrow, Value: "=>", Pos: pos})
+			pos += 2
+			continue
+		}
+
+		if strings.ContainsRune("()[]{}?:.,|=", rune(c)) {
+			tokens = append(tokens, token{Type: tokenPunctuation, Value: string(c), Pos: pos})
+			pos++
+			continue
+		}
+
+		return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+	}
+
+	return append(tokens, token{Type: tokenEOF, Pos: len(input)}), nil
+}
+
+// scanString returns the position after the closing quote of the string starting at pos.
+// Interpolations in double quoted strings may contain strings themselves.
+func scanString(input string, pos int) (int, error) {
+	quote := input[pos]
+	i := pos + 1
+
+	for i < len(input) {
+		switch {
+		case input[i] == '\\':
+			i += 2
+		case input[i] == quote:
+			return i + 1, nil
+		case quote == '"' && strings.HasPrefix(input[i:], "#{"):
+			end, err := scanInterpolation(input, i+2)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+
+	return 0, fmt.Errorf("unclosed string starting at position %d", pos)
+}
+
+// scanInterpolation returns the position after the closing brace of an interpolation starting at pos.
+func scanInterpolation(input string, pos int) (int, error) {
+	depth := 1
+	i := pos
+
+	for i < len(input) {
+		switch input[i] {
+		case '\'', '"':
+			end, err := scanString(input, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+		i++
+	}
+
+	return 0, fmt.Errorf("unclosed interpolation starting at position %d", pos)
+}
+
+func scanNumber(input string, pos int) int {
+	end := pos
+	scanDigits := func() {
+		for end < len(input) && (isDigit(input[end]) || (input[end] == '_' && end+1 < len(input) && isDigit(input[end+1]))) {
+			end++
+		}
+	}
+
+	scanDigits()
+
+	// A dot is only part of the number when a digit follows, 1..5 is a range
+	if end+1 < len(input) && input[end] == '.' && isDigit(input[end+1]) {
+		end++
+		scanDigits()
+	}
+
+	if end+1 < len(input) && (input[end] == 'e' || input[end] == 'E') {
+		exponent := end + 1
+		if input[exponent] == '+' || input[exponent] == '-' {
+			exponent++
+		}
+		if exponent < len(input) && isDigit(input[exponent]) {
+			end = exponent
+			scanDigits()
+		}
+	}
+
+	return end
+}
+
+// matchOperator returns the operator starting at pos and the position after it.
+// Word operators must not be followed by a name character and may contain any whitespace between their words.
+func matchOperator(input string, pos int) (string, int) {
+	for _, op := range lexerOperators {
+		if end, ok := matchOperatorAt(input, pos, op); ok {
+			return op, end
+		}
+	}
+
+	return "", pos
+}
+
+func matchOperatorAt(input string, pos int, op string) (int, bool) {
+	i := pos
+	words := strings.Split(op, " ")
+
+	for w, word := range words {
+		if w > 0 {
+			start := i
+			for i < len(input) && isWhitespace(rune(input[i])) {
+				i++
+			}
+			if i == start {
+				return 0, false
+			}
+		}
+
+		if !strings.HasPrefix(input[i:], word) {
+			return 0, false
+		}
+		i += len(word)
+	}
+
+	if isNameChar(op[len(op)-1]) && i < len(input) && isNameChar(input[i]) {
+		return 0, false
+	}
+
+	return i, true
+}
@@ -0,0 +1,386 @@
+package twigparser
+
+import (
+	"strings"
+)
+
+// Expression represents a node of a parsed Twig expression like {{ product.name|upper }}.
+type Expression interface {
+	// Dump outputs the expression back into source code.
+	Dump() string
+}
+
+// NameExpression is a variable like product.
+type NameExpression struct {
+	Name string
+}
+
+func (n *NameExpression) Dump() string {
+	return n.Name
+}
+
+// StringExpression is a string literal. Value holds the unescaped content.
+type StringExpression struct {
+	Value string
+}
+
+func (s *StringExpression) Dump() string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s.Value) + "'"
+}
+
+// InterpolatedStringExpression is a double quoted string with #{...} interpolations.
+// Parts contains StringExpression for the text and the interpolated expressions.
+type InterpolatedStringExpression struct {
+	Parts []Expression
+}
+
+func (s *InterpolatedStringExpression) Dump() string {
+	var sb strings.Builder
+	sb.WriteString(`"`)
+	for _, part := range s.Parts {
+		if str, ok := part.(*StringExpression); ok {
+			sb.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`, `#{`, `\#{`).Replace(str.Value))
+			continue
+		}
+		sb.WriteString("#{" + part.Dump() + "}")
+	}
+	sb.WriteString(`"`)
+	return sb.String()
+}
+
+// NumberExpression is a number literal, the value is kept as written.
+type NumberExpression struct {
+	Value string
+}
+
+func (n *NumberExpression) Dump() string {
+	return n.Value
+}
+
+// ConstantExpression is one of the constants true, false, null or none.
+type ConstantExpression struct {
+	Value string
+}
+
+func (c *ConstantExpression) Dump() string {
+	return c.Value
+}
+
+// ArrayExpression is an array literal like [1, 2].
+type ArrayExpression struct {
+	Elements []Expression
+}
+
+func (a *ArrayExpression) Dump() string {
+	return "[" + dumpExpressions(a.Elements) + "]"
+}
+
+// HashPair is one key/value pair of a hash. Keys written as (expression) are Computed,
+// otherwise the key is a NameExpression, StringExpression or NumberExpression.
+type HashPair struct {
+	Key      Expression
+	Value    Expression
+	Computed bool
+}
+
+// HashExpression is a hash literal like { foo: 'bar' }.
+type HashExpression struct {
+	Pairs []HashPair
+}
+
+func (h *HashExpression) Dump() string {
+	if len(h.Pairs) == 0 {
+		return "{}"
+	}
+
+	parts := make([]string, 0, len(h.Pairs))
+	for _, pair := range h.Pairs {
+		key := pair.Key.Dump()
+		if pair.Computed {
+			key = "(" + key + ")"
+		}
+		parts = append(parts, key+": "+pair.Value.Dump())
+	}
+	return "{ " + strings.Join(parts, ", ") + " }"
+}
+
+// UnaryExpression is an expression with a prefix operator like not or -.
+type UnaryExpression struct {
+	Operator string
+	Operand  Expression
+}
+
+func (u *UnaryExpression) Dump() string {
+	operand := dumpOperand(u.Operand, unaryOperators[u.Operator], false)
+	if u.Operator == "not" {
+		return "not " + operand
+	}
+	return u.Operator + operand
+}
+
+// BinaryExpression is an expression with an infix operator like ~, and or not in.
+type BinaryExpression struct {
+	Operator string
+	Left     Expression
+	Right    Expression
+}
+
+func (b *BinaryExpression) Dump() string {
+	op := binaryOperators[b.Operator]
+	left := dumpOperand(b.Left, op.precedence, op.rightAssociative)
+	right := dumpOperand(b.Right, op.precedence, !op.rightAssociative)
+	if b.Operator == ".." {
+		return left + ".." + right
+	}
+	return left + " " + b.Operator + " " + right
+}
+
+// ConditionalExpression is the ternary operator. Then is nil for a ?: b and Else is nil for a ? b.
+type ConditionalExpression struct {
+	Condition Expression
+	Then      Expression
+	Else      Expression
+}
+
+func (c *ConditionalExpression) Dump() string {
+	condition := dumpOperand(c.Condition, 1, false)
+	switch {
+	case c.Then == nil:
+		return condition + " ?: " + c.Else.Dump()
+	case c.Else == nil:
+		return condition + " ? " + dumpOperand(c.Then, 1, false)
+	default:
+		return condition + " ? " + dumpOperand(c.Then, 1, false) + " : " + c.Else.Dump()
+	}
+}
+
+// Argument is a positional or, when Name is set, a named argument of a call.
+type Argument struct {
+	Name  string
+	Value Expression
+}
+
+func dumpArguments(arguments []Argument) string {
+	parts := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		if argument.Name != "" {
+			parts = append(parts, argument.Name+"="+argument.Value.Dump())
+			continue
+		}
+		parts = append(parts, argument.Value.Dump())
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// FunctionExpression is a function call like path('frontend.home.page').
+type FunctionExpression struct {
+	Name      string
+	Arguments []Argument
+}
+
+func (f *FunctionExpression) Dump() string {
+	return f.Name + dumpArguments(f.Arguments)
+}
+
+// FilterExpression applies a filter to Node like product.name|raw.
+// Node is nil for the filters of an {% apply %} tag.
+type FilterExpression struct {
+	Node      Expression
+	Name      string
+	Arguments []Argument
+	// HasArguments is true when the filter was written with parentheses
+	HasArguments bool
+}
+
+func (f *FilterExpression) Dump() string {
+	var sb strings.Builder
+	if f.Node != nil {
+		sb.WriteString(dumpOperand(f.Node, postfixPrecedence, false))
+		sb.WriteString("|")
+	}
+	sb.WriteString(f.Name)
+	if f.HasArguments || len(f.Arguments) > 0 {
+		sb.WriteString(dumpArguments(f.Arguments))
+	}
+	return sb.String()
+}
+
+// TestExpression is a test like product is defined or index is not divisible by(3).
+type TestExpression struct {
+	Node      Expression
+	Name      string
+	Negated   bool
+	Arguments []Argument
+}
+
+func (t *TestExpression) Dump() string {
+	var sb strings.Builder
+	sb.WriteString(dumpOperand(t.Node, binaryOperators["is"].precedence, false))
+	sb.WriteString(" is ")
+	if t.Negated {
+		sb.WriteString("not ")
+	}
+	sb.WriteString(t.Name)
+	if len(t.Arguments) > 0 {
+		sb.WriteString(dumpArguments(t.Arguments))
+	}
+	return sb.String()
+}
+
+// GetAttrExpression accesses an attribute or calls a method like product.translated.name or page.getHeader().
+type GetAttrExpression struct {
+	Node      Expression
+	Attribute string
+	Arguments []Argument
+	IsCall    bool
+}
+
+func (g *GetAttrExpression) Dump() string {
+	dump := dumpOperand(g.Node, postfixPrecedence, false) + "." + g.Attribute
+	if g.IsCall {
+		dump += dumpArguments(g.Arguments)
+	}
+	return dump
+}
+
+// SubscriptExpression accesses an array key like config['name'].
+type SubscriptExpression struct {
+	Node Expression
+	Key  Expression
+}
+
+func (s *SubscriptExpression) Dump() string {
+	return dumpOperand(s.Node, postfixPrecedence, false) + "[" + s.Key.Dump() + "]"
+}
+
+// SliceExpression is the slice shorthand like items[1:2], Start and Length are optional.
+type SliceExpression struct {
+	Node   Expression
+	Start  Expression
+	Length Expression
+}
+
+func (s *SliceExpression) Dump() string {
+	var sb strings.Builder
+	sb.WriteString(dumpOperand(s.Node, postfixPrecedence, false))
+	sb.WriteString("[")
+	if s.Start != nil {
+		sb.WriteString(s.Start.Dump())
+	}
+	sb.WriteString(":")
+	if s.Length != nil {
+		sb.WriteString(s.Length.Dump())
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// ArrowFunctionExpression is an arrow function argument like item => item.active.
+type ArrowFunctionExpression struct {
+	Parameters []string
+	Body       Expression
+}
+
+func (a *ArrowFunctionExpression) Dump() string {
+	if len(a.Parameters) == 1 {
+		return a.Parameters[0] + " => " + a.Body.Dump()
+	}
+	return "(" + joinNames(a.Parameters) + ") => " + a.Body.Dump()
+}
+
+// postfixPrecedence is higher than all operators, filters and attributes bind the tightest.
+const postfixPrecedence = 1000
+
+// precedenceOf returns how tight the expression binds, to decide when Dump needs parentheses.
+func precedenceOf(expr Expression) int {
+	switch e := expr.(type) {
+	case *BinaryExpression:
+		return binaryOperators[e.Operator].precedence
+	case *UnaryExpression:
+		return unaryOperators[e.Operator]
+	case *TestExpression:
+		return binaryOperators["is"].precedence
+	case *ConditionalExpression, *ArrowFunctionExpression:
+		return 0
+	}
+	return postfixPrecedence
+}
+
+// dumpOperand dumps expr and wraps it in parentheses when it binds less tight than its parent.
+// When sameNeedsParens is true, an operand with the same precedence is wrapped too.
+func dumpOperand(expr Expression, precedence int, sameNeedsParens bool) string {
+	own := precedenceOf(expr)
+	if own < precedence || (own == precedence && sameNeedsParens && own != postfixPrecedence) {
+		return "(" + expr.Dump() + ")"
+	}
+	return expr.Dump()
+}
+
+func dumpExpressions(expressions []Expression) string {
+	parts := make([]string, 0, len(expressions))
+	for _, expr := range expressions {
+		parts = append(parts, expr.Dump())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// InspectExpression traverses the expression depth-first. The children of an expression
+// are only visited when fn returns true.
+func InspectExpression(expr Expression, fn func(Expression) bool) {
+	if expr == nil || !fn(expr) {
+		return
+	}
+
+	inspectArguments := func(arguments []Argument) {
+		for _, argument := range arguments {
+			InspectExpression(argument.Value, fn)
+		}
+	}
+
+	switch e := expr.(type) {
+	case *InterpolatedStringExpression:
+		for _, part := range e.Parts {
+			InspectExpression(part, fn)
+		}
+	case *ArrayExpression:
+		for _, element := range e.Elements {
+			InspectExpression(element, fn)
+		}
+	case *HashExpression:
+		for _, pair := range e.Pairs {
+			if pair.Computed {
+				InspectExpression(pair.Key, fn)
+			}
+			InspectExpression(pair.Value, fn)
+		}
+	case *UnaryExpression:
+		InspectExpression(e.Operand, fn)
+	case *BinaryExpression:
+		InspectExpression(e.Left, fn)
+		InspectExpression(e.Right, fn)
+	case *ConditionalExpression:
+		InspectExpression(e.Condition, fn)
+		InspectExpression(e.Then, fn)
+		InspectExpression(e.Else, fn)
+	case *FunctionExpression:
+		inspectArguments(e.Arguments)
+	case *FilterExpression:
+		InspectExpression(e.Node, fn)
+		inspectArguments(e.Arguments)
+	case *TestExpression:
+		InspectExpression(e.Node, fn)
+		inspectArguments(e.Arguments)
+	case *GetAttrExpression:
+		InspectExpression(e.Node, fn)
+		inspectArguments(e.Arguments)
+	case *SubscriptExpression:
+		InspectExpression(e.Node, fn)
+		InspectExpression(e.Key, fn)
+	case *SliceExpression:
+		InspectExpression(e.Node, fn)
+		InspectExpression(e.Start, fn)
+		InspectExpression(e.Length, fn)
+	case *ArrowFunctionExpression:
+		InspectExpression(e.Body, fn)
+	}
+}
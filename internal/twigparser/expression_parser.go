@@ -0,0 +1,785 @@
+package twigparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// twoWordTests are the builtin tests with a name of two words, they take one argument which may be written without parentheses.
+var twoWordTests = map[string]string{
+	"divisible": "by",
+	"same":      "as",
+}
+
+// tagsWithoutExpressions are tags whose content is not an expression.
+var tagsWithoutExpressions = map[string]bool{
+	"block":     true,
+	"macro":     true,
+	"verbatim":  true,
+	"spaceless": true,
+	"else":      true,
+}
+
+// tagKeywords are skipped between the expressions of a tag like {% sw_include 'foo.html.twig' with { a: 1 } only %}.
+var tagKeywords = map[string]bool{
+	"with":      true,
+	"only":      true,
+	"as":        true,
+	"ignore":    true,
+	"missing":   true,
+	"import":    true,
+	"from":      true,
+	"using":     true,
+	"sandboxed": true,
+	"style":     true,
+}
+
+// Tag is a parsed {% %} tag with the expressions it contains.
+type Tag struct {
+	Name        string
+	Expressions []Expression
+}
+
+// ParseExpression parses the content of a {{ }} tag like "product.name|upper" into an expression.
+func ParseExpression(input string) (Expression, error) {
+	p, err := newExpressionParser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := p.parseExpression(0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current().Type != tokenEOF {
+		return nil, p.unexpected()
+	}
+
+	return expr, nil
+}
+
+// ParseTag parses the content of a {% %} tag like "if product.available" into the tag name and its expressions.
+// Keywords like with, only or as between the expressions are skipped. End tags and tags like block or macro have no expressions.
+func ParseTag(content string) (*Tag, error) {
+	p, err := newExpressionParser(strings.Trim(strings.TrimSpace(content), "-~"))
+	if err != nil {
+		return nil, err
+	}
+
+	name := p.next()
+	if name.Type != tokenName {
+		return nil, fmt.Errorf("expected a tag name at position %d", name.Pos)
+	}
+
+	tag := &Tag{Name: name.Value}
+
+	if tagsWithoutExpressions[tag.Name] || strings.HasPrefix(tag.Name, "end") {
+		return tag, nil
+	}
+
+	switch tag.Name {
+	case "for":
+		// The loop variables are declared and not used
+		for !p.test(tokenOperator, "in") {
+			if p.current().Type == tokenEOF {
+				return nil, p.unexpected()
+			}
+			p.next()
+		}
+		p.next()
+	case "set":
+		// Block assignments like {% set foo %} have no expression
+		for !p.test(tokenPunctuation, "=") {
+			if p.current().Type == tokenEOF {
+				return tag, nil
+			}
+			p.next()
+		}
+		p.next()
+	case "apply":
+		filters, err := p.parseFilters(nil)
+		if err != nil {
+			return nil, err
+		}
+		tag.Expressions = append(tag.Expressions, filters)
+		return tag, nil
+	}
+
+	for p.current().Type != tokenEOF {
+		if p.test(tokenPunctuation, ",") || (p.current().Type == tokenName && tagKeywords[p.current().Value]) {
+			p.next()
+			continue
+		}
+
+		// The condition of a {% for item in items if item.active %} loop
+		if tag.Name == "for" && p.test(tokenName, "if") {
+			p.next()
+			continue
+		}
+
+		expr, err := p.parseExpression(0, false)
+		if err != nil {
+			return nil, err
+		}
+		tag.Expressions = append(tag.Expressions, expr)
+	}
+
+	return tag, nil
+}
+
+// ParseExpression parses the expression printed by the node.
+func (p *PrintNode) ParseExpression() (Expression, error) {
+	return ParseExpression(p.Expression)
+}
+
+type expressionParser struct {
+	tokens []token
+	pos    int
+}
+
+func newExpressionParser(input string) (*expressionParser, error) {
+	tokens, err := tokenizeExpression(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &expressionParser{tokens: tokens}, nil
+}
+
+func (p *expressionParser) current() token {
+	return p.peek(0)
+}
+
+func (p *expressionParser) peek(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *expressionParser) next() token {
+	t := p.current()
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *expressionParser) test(t tokenType, value string) bool {
+	current := p.current()
+	return current.Type == t && current.Value == value
+}
+
+func (p *expressionParser) expect(t tokenType, value string) error {
+	if !p.test(t, value) {
+		return fmt.Errorf("expected %q at position %d", value, p.current().Pos)
+	}
+	p.next()
+	return nil
+}
+
+func (p *expressionParser) unexpected() error {
+	current := p.current()
+	if current.Type == tokenEOF {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected %q at position %d", current.Value, current.Pos)
+}
+
+// parseExpression parses operators with at least the given precedence, the ternary operator is only parsed on the top level.
+func (p *expressionParser) parseExpression(precedence int, allowArrow bool) (Expression, error) {
+	if allowArrow {
+		if arrow, err := p.parseArrow(); arrow != nil || err != nil {
+			return arrow, err
+		}
+	}
+
+	expr, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		current := p.current()
+		if current.Type != tokenOperator {
+			break
+		}
+
+		op, ok := binaryOperators[current.Value]
+		if !ok || op.precedence < precedence {
+			break
+		}
+
+		p.next()
+
+		if current.Value == "is" || current.Value == "is not" {
+			if expr, err = p.parseTest(expr, current.Value == "is not"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		nextPrecedence := op.precedence + 1
+		if op.rightAssociative {
+			nextPrecedence = op.precedence
+		}
+
+		right, err := p.parseExpression(nextPrecedence, false)
+		if err != nil {
+			return nil, err
+		}
+
+		expr = &BinaryExpression{Operator: current.Value, Left: expr, Right: right}
+	}
+
+	if precedence == 0 {
+		return p.parseConditional(expr)
+	}
+
+	return expr, nil
+}
+
+func (p *expressionParser) parseConditional(expr Expression) (Expression, error) {
+	for p.test(tokenPunctuation, "?") {
+		p.next()
+
+		conditional := &ConditionalExpression{Condition: expr}
+
+		if p.test(tokenPunctuation, ":") {
+			p.next()
+
+			elseExpr, err := p.parseExpression(0, false)
+			if err != nil {
+				return nil, err
+			}
+			conditional.Else = elseExpr
+		} else {
+			thenExpr, err := p.parseExpression(0, false)
+			if err != nil {
+				return nil, err
+			}
+			conditional.Then = thenExpr
+
+			if p.test(tokenPunctuation, ":") {
+				p.next()
+
+				elseExpr, err := p.parseExpression(0, false)
+				if err != nil {
+					return nil, err
+				}
+				conditional.Else = elseExpr
+			}
+		}
+
+		expr = conditional
+	}
+
+	return expr, nil
+}
+
+func (p *expressionParser) parseUnary() (Expression, error) {
+	current := p.current()
+
+	if current.Type == tokenOperator {
+		if precedence, ok := unaryOperators[current.Value]; ok {
+			p.next()
+
+			operand, err := p.parseExpression(precedence, false)
+			if err != nil {
+				return nil, err
+			}
+
+			return &UnaryExpression{Operator: current.Value, Operand: operand}, nil
+		}
+	}
+
+	if p.test(tokenPunctuation, "(") {
+		p.next()
+
+		expr, err := p.parseExpression(0, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunctuation, ")"); err != nil {
+			return nil, err
+		}
+
+		return p.parsePostfix(expr)
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (Expression, error) {
+	current := p.current()
+
+	var expr Expression
+
+	switch current.Type {
+	case tokenName:
+		p.next()
+
+		switch strings.ToLower(current.Value) {
+		case "true", "false", "null", "none":
+			expr = &ConstantExpression{Value: current.Value}
+		default:
+			if p.test(tokenPunctuation, "(") {
+				arguments, err := p.parseArguments()
+				if err != nil {
+					return nil, err
+				}
+				expr = &FunctionExpression{Name: current.Value, Arguments: arguments}
+			} else {
+				expr = &NameExpression{Name: current.Value}
+			}
+		}
+	case tokenNumber:
+		p.next()
+		expr = &NumberExpression{Value: current.Value}
+	case tokenString:
+		p.next()
+
+		str, err := parseString(current)
+		if err != nil {
+			return nil, err
+		}
+		expr = str
+	case tokenPunctuation:
+		var err error
+
+		switch current.Value {
+		case "[":
+			expr, err = p.parseArray()
+		case "{":
+			expr, err = p.parseHash()
+		default:
+			return nil, p.unexpected()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.unexpected()
+	}
+
+	return p.parsePostfix(expr)
+}
+
+func (p *expressionParser) parseArray() (Expression, error) {
+	if err := p.expect(tokenPunctuation, "["); err != nil {
+		return nil, err
+	}
+
+	array := &ArrayExpression{}
+
+	for !p.test(tokenPunctuation, "]") {
+		if len(array.Elements) > 0 {
+			if err := p.expect(tokenPunctuation, ","); err != nil {
+				return nil, err
+			}
+
+			// Trailing comma
+			if p.test(tokenPunctuation, "]") {
+				break
+			}
+		}
+
+		element, err := p.parseExpression(0, false)
+		if err != nil {
+			return nil, err
+		}
+		array.Elements = append(array.Elements, element)
+	}
+
+	p.next()
+
+	return array, nil
+}
+
+func (p *expressionParser) parseHash() (Expression, error) {
+	if err := p.expect(tokenPunctuation, "{"); err != nil {
+		return nil, err
+	}
+
+	hash := &HashExpression{}
+
+	for !p.test(tokenPunctuation, "}") {
+		if len(hash.Pairs) > 0 {
+			if err := p.expect(tokenPunctuation, ","); err != nil {
+				return nil, err
+			}
+
+			// Trailing comma
+			if p.test(tokenPunctuation, "}") {
+				break
+			}
+		}
+
+		var pair HashPair
+
+		key := p.current()
+
+		switch {
+		case key.Type == tokenName || (key.Type == tokenOperator && isNameStart(key.Value[0])):
+			p.next()
+			pair.Key = &NameExpression{Name: key.Value}
+
+			// Shorthand like { product } for { product: product }
+			if p.test(tokenPunctuation, ",") || p.test(tokenPunctuation, "}") {
+				pair.Value = &NameExpression{Name: key.Value}
+				hash.Pairs = append(hash.Pairs, pair)
+				continue
+			}
+		case key.Type == tokenString:
+			p.next()
+
+			str, err := parseString(key)
+			if err != nil {
+				return nil, err
+			}
+			pair.Key = str
+		case key.Type == tokenNumber:
+			p.next()
+			pair.Key = &NumberExpression{Value: key.Value}
+		case key.Type == tokenPunctuation && key.Value == "(":
+			p.next()
+
+			expr, err := p.parseExpression(0, false)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect(tokenPunctuation, ")"); err != nil {
+				return nil, err
+			}
+
+			pair.Key = expr
+			pair.Computed = true
+		default:
+			return nil, p.unexpected()
+		}
+
+		if err := p.expect(tokenPunctuation, ":"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseExpression(0, false)
+		if err != nil {
+			return nil, err
+		}
+		pair.Value = value
+
+		hash.Pairs = append(hash.Pairs, pair)
+	}
+
+	p.next()
+
+	return hash, nil
+}
+
+// parsePostfix parses attribute access, subscripts and filters following an expression.
+func (p *expressionParser) parsePostfix(expr Expression) (Expression, error) {
+	for {
+		current := p.current()
+		if current.Type != tokenPunctuation {
+			return expr, nil
+		}
+
+		var err error
+
+		switch current.Value {
+		case ".":
+			p.next()
+
+			attribute := p.next()
+			if attribute.Type != tokenName && attribute.Type != tokenNumber && (attribute.Type != tokenOperator || !isNameStart(attribute.Value[0])) {
+				return nil, fmt.Errorf("expected an attribute name at position %d", attribute.Pos)
+			}
+
+			getAttr := &GetAttrExpression{Node: expr, Attribute: attribute.Value}
+
+			if p.test(tokenPunctuation, "(") {
+				getAttr.IsCall = true
+				if getAttr.Arguments, err = p.parseArguments(); err != nil {
+					return nil, err
+				}
+			}
+
+			expr = getAttr
+		case "[":
+			if expr, err = p.parseSubscript(expr); err != nil {
+				return nil, err
+			}
+		case "|":
+			if expr, err = p.parseFilters(expr); err != nil {
+				return nil, err
+			}
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *expressionParser) parseSubscript(node Expression) (Expression, error) {
+	if err := p.expect(tokenPunctuation, "["); err != nil {
+		return nil, err
+	}
+
+	var start Expression
+	if !p.test(tokenPunctuation, ":") {
+		var err error
+		if start, err = p.parseExpression(0, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.test(tokenPunctuation, ":") {
+		p.next()
+
+		slice := &SliceExpression{Node: node, Start: start}
+
+		if !p.test(tokenPunctuation, "]") {
+			length, err := p.parseExpression(0, false)
+			if err != nil {
+				return nil, err
+			}
+			slice.Length = length
+		}
+
+		if err := p.expect(tokenPunctuation, "]"); err != nil {
+			return nil, err
+		}
+
+		return slice, nil
+	}
+
+	if err := p.expect(tokenPunctuation, "]"); err != nil {
+		return nil, err
+	}
+
+	return &SubscriptExpression{Node: node, Key: start}, nil
+}
+
+// parseFilters parses a chain of filters like |trans|upper. Without node it parses the filters of an {% apply %} tag.
+func (p *expressionParser) parseFilters(node Expression) (Expression, error) {
+	expr := node
+
+	for first := true; first || p.test(tokenPunctuation, "|"); first = false {
+		// The filters of an apply tag start without a pipe
+		if !first || node != nil {
+			p.next()
+		}
+
+		name := p.next()
+		if name.Type != tokenName {
+			return nil, fmt.Errorf("expected a filter name at position %d", name.Pos)
+		}
+
+		filter := &FilterExpression{Node: expr, Name: name.Value}
+
+		if p.test(tokenPunctuation, "(") {
+			filter.HasArguments = true
+
+			arguments, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			filter.Arguments = arguments
+		}
+
+		expr = filter
+	}
+
+	return expr, nil
+}
+
+func (p *expressionParser) parseTest(node Expression, negated bool) (Expression, error) {
+	name := p.next()
+	if name.Type != tokenName {
+		return nil, fmt.Errorf("expected a test name at position %d", name.Pos)
+	}
+
+	test := &TestExpression{Node: node, Name: name.Value, Negated: negated}
+
+	secondWord, twoWords := twoWordTests[name.Value]
+	if twoWords && p.test(tokenName, secondWord) {
+		p.next()
+		test.Name += " " + secondWord
+	}
+
+	var err error
+
+	switch {
+	case p.test(tokenPunctuation, "("):
+		test.Arguments, err = p.parseArguments()
+	case twoWords:
+		var argument Expression
+		if argument, err = p.parsePrimary(); err == nil {
+			test.Arguments = []Argument{{Value: argument}}
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return test, nil
+}
+
+func (p *expressionParser) parseArguments() ([]Argument, error) {
+	if err := p.expect(tokenPunctuation, "("); err != nil {
+		return nil, err
+	}
+
+	arguments := []Argument{}
+
+	for !p.test(tokenPunctuation, ")") {
+		if len(arguments) > 0 {
+			if err := p.expect(tokenPunctuation, ","); err != nil {
+				return nil, err
+			}
+
+			// Trailing comma
+			if p.test(tokenPunctuation, ")") {
+				break
+			}
+		}
+
+		var argument Argument
+
+		// Named arguments like format_date(pattern='short') or format_date(pattern: 'short')
+		if p.current().Type == tokenName && (p.peek(1).Type == tokenPunctuation && (p.peek(1).Value == "=" || p.peek(1).Value == ":")) {
+			argument.Name = p.next().Value
+			p.next()
+		}
+
+		value, err := p.parseExpression(0, true)
+		if err != nil {
+			return nil, err
+		}
+		argument.Value = value
+
+		arguments = append(arguments, argument)
+	}
+
+	p.next()
+
+	return arguments, nil
+}
+
+// parseArrow parses an arrow function like item => item.active or (key, value) => value, it returns nil for other expressions.
+func (p *expressionParser) parseArrow() (Expression, error) {
+	var parameters []string
+
+	switch {
+	case p.current().Type == tokenName && p.peek(1).Type == tokenArrow:
+		parameters = []string{p.current().Value}
+		p.pos += 2
+	case p.test(tokenPunctuation, "("):
+		i := 1
+		for p.peek(i).Type == tokenName {
+			parameters = append(parameters, p.peek(i).Value)
+			i++
+
+			if p.peek(i).Type != tokenPunctuation || p.peek(i).Value != "," {
+				break
+			}
+			i++
+		}
+
+		if len(parameters) == 0 || p.peek(i).Type != tokenPunctuation || p.peek(i).Value != ")" || p.peek(i+1).Type != tokenArrow {
+			//nolint: nilnil
+			return nil, nil
+		}
+
+		p.pos += i + 2
+	default:
+		//nolint: nilnil
+		return nil, nil
+	}
+
+	body, err := p.parseExpression(0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArrowFunctionExpression{Parameters: parameters, Body: body}, nil
+}
+
+// parseString unescapes a string token and parses the interpolations of double quoted strings.
+func parseString(t token) (Expression, error) {
+	if t.Quote != '"' || !strings.Contains(t.Value, "#{") {
+		return &StringExpression{Value: unescapeString(t.Value)}, nil
+	}
+
+	interpolated := &InterpolatedStringExpression{}
+	var text strings.Builder
+
+	for i := 0; i < len(t.Value); {
+		switch {
+		case t.Value[i] == '\\' && i+1 < len(t.Value):
+			text.WriteString(unescapeString(t.Value[i : i+2]))
+			i += 2
+		case strings.HasPrefix(t.Value[i:], "#{"):
+			end, err := scanInterpolation(t.Value, i+2)
+			if err != nil {
+				return nil, err
+			}
+
+			expr, err := ParseExpression(t.Value[i+2 : end-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid interpolation in string at position %d: %w", t.Pos, err)
+			}
+
+			if text.Len() > 0 {
+				interpolated.Parts = append(interpolated.Parts, &StringExpression{Value: text.String()})
+				text.Reset()
+			}
+
+			interpolated.Parts = append(interpolated.Parts, expr)
+			i = end
+		default:
+			text.WriteByte(t.Value[i])
+			i++
+		}
+	}
+
+	if text.Len() > 0 {
+		interpolated.Parts = append(interpolated.Parts, &StringExpression{Value: text.String()})
+	}
+
+	return interpolated, nil
+}
+
+func unescapeString(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 >= len(value) {
+			sb.WriteByte(value[i])
+			continue
+		}
+
+		i++
+
+		switch value[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			sb.WriteByte(value[i])
+		}
+	}
+
+	return sb.String()
+}
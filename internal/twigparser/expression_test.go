@@ -0,0 +1,216 @@
+package twigparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpressionRoundTrip(t *testing.T) {
+	testcases := []string{
+		`product`,
+		`'Hello \'World\''`,
+		`"Hello #{customer.firstName|upper}!"`,
+		`1.5`,
+		`true`,
+		`[1, 'two', three]`,
+		`{ foo: 1, 'bar': 2, (key): 3 }`,
+		`not product.available`,
+		`-1`,
+		`a + b * c`,
+		`(a + b) * c`,
+		`a - (b - c)`,
+		`2 ** 3 ** 2`,
+		`a ~ b|upper`,
+		`(a ~ b)|upper`,
+		`product.name ?? 'fallback'`,
+		`item not in items`,
+		`name starts with 'sw'`,
+		`a ? b : c`,
+		`a ?: c`,
+		`page.header.navigation.active.id`,
+		`page.getHeader().getNavigation()`,
+		`config['core.basicInformation.shopName']`,
+		`items[1:2]`,
+		`items[:2]`,
+		`'now'|format_date(pattern='short', locale=app.request.locale)`,
+		`path('frontend.detail.page', { productId: product.id })`,
+		`items|filter(item => item.active)|map((key, value) => value.name)|join(', ')`,
+		`product is defined`,
+		`index is not divisible by(3)`,
+		`a is same as(b) and c is not null`,
+		`content|raw`,
+		`block('base_content')`,
+		`1..10`,
+		`a b-and b`,
+	}
+
+	for _, tc := range testcases {
+		expr, err := ParseExpression(tc)
+		assert.NoError(t, err, tc)
+
+		if err == nil {
+			assert.Equal(t, tc, expr.Dump())
+		}
+	}
+}
+
+func TestParseExpressionPrecedence(t *testing.T) {
+	expr, err := ParseExpression("a ~ b|raw")
+	assert.NoError(t, err)
+
+	binary, ok := expr.(*BinaryExpression)
+	assert.True(t, ok)
+	assert.Equal(t, "~", binary.Operator)
+	assert.IsType(t, &FilterExpression{}, binary.Right)
+
+	expr, err = ParseExpression("not a and b")
+	assert.NoError(t, err)
+
+	binary, ok = expr.(*BinaryExpression)
+	assert.True(t, ok)
+	assert.Equal(t, "and", binary.Operator)
+	assert.IsType(t, &UnaryExpression{}, binary.Left)
+
+	expr, err = ParseExpression("-a|abs")
+	assert.NoError(t, err)
+
+	unary, ok := expr.(*UnaryExpression)
+	assert.True(t, ok)
+	assert.IsType(t, &FilterExpression{}, unary.Operand)
+}
+
+func TestParseExpressionNodes(t *testing.T) {
+	expr, err := ParseExpression("product.translated.name|sw_sanitize|raw")
+	assert.NoError(t, err)
+
+	raw, ok := expr.(*FilterExpression)
+	assert.True(t, ok)
+	assert.Equal(t, "raw", raw.Name)
+	assert.False(t, raw.HasArguments)
+
+	sanitize, ok := raw.Node.(*FilterExpression)
+	assert.True(t, ok)
+	assert.Equal(t, "sw_sanitize", sanitize.Name)
+
+	attr, ok := sanitize.Node.(*GetAttrExpression)
+	assert.True(t, ok)
+	assert.Equal(t, "name", attr.Attribute)
+	assert.False(t, attr.IsCall)
+
+	expr, err = ParseExpression("'foo'|trans({ '%name%': name }, 'messages')")
+	assert.NoError(t, err)
+
+	trans, ok := expr.(*FilterExpression)
+	assert.True(t, ok)
+	assert.Len(t, trans.Arguments, 2)
+	assert.IsType(t, &HashExpression{}, trans.Arguments[0].Value)
+	assert.Equal(t, &StringExpression{Value: "foo"}, trans.Node)
+
+	expr, err = ParseExpression("'now'|date(format: 'Y')")
+	assert.NoError(t, err)
+	assert.Equal(t, "format", expr.(*FilterExpression).Arguments[0].Name)
+
+	expr, err = ParseExpression("a is divisible by 3")
+	assert.NoError(t, err)
+
+	test, ok := expr.(*TestExpression)
+	assert.True(t, ok)
+	assert.Equal(t, "divisible by", test.Name)
+	assert.Equal(t, &NumberExpression{Value: "3"}, test.Arguments[0].Value)
+
+	expr, err = ParseExpression(`"#{a}-#{b}"`)
+	assert.NoError(t, err)
+
+	str, ok := expr.(*InterpolatedStringExpression)
+	assert.True(t, ok)
+	assert.Len(t, str.Parts, 3)
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	testcases := []string{
+		``,
+		`a +`,
+		`foo(`,
+		`[1, 2`,
+		`{ foo 1 }`,
+		`'unclosed`,
+		`a b`,
+		`a|`,
+		`a.`,
+		`$foo`,
+	}
+
+	for _, tc := range testcases {
+		_, err := ParseExpression(tc)
+		assert.Error(t, err, tc)
+	}
+}
+
+func TestInspectExpression(t *testing.T) {
+	expr, err := ParseExpression("a ~ foo(b, c|upper) ~ (d ? e.f : g[h])")
+	assert.NoError(t, err)
+
+	var names []string
+
+	InspectExpression(expr, func(expr Expression) bool {
+		if name, ok := expr.(*NameExpression); ok {
+			names = append(names, name.Name)
+		}
+		return true
+	})
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "g", "h"}, names)
+}
+
+func TestParseTag(t *testing.T) {
+	testcases := []struct {
+		content     string
+		name        string
+		expressions []string
+	}{
+		{content: "if product.available and not product.isCloseout", name: "if", expressions: []string{"product.available and not product.isCloseout"}},
+		{content: "- elseif a -", name: "elseif", expressions: []string{"a"}},
+		{content: "for key, item in items|filter(i => i.active) if item.visible", name: "for", expressions: []string{"items|filter(i => i.active)", "item.visible"}},
+		{content: "set a, b = 1, c|upper", name: "set", expressions: []string{"1", "c|upper"}},
+		{content: "set content", name: "set"},
+		{content: "sw_include '@Storefront/storefront/component/product/card/box.html.twig' with { product: product } only", name: "sw_include", expressions: []string{"'@Storefront/storefront/component/product/card/box.html.twig'", "{ product: product }"}},
+		{content: "sw_icon 'arrow-head-right' style { size: 'xs' }", name: "sw_icon", expressions: []string{"'arrow-head-right'", "{ size: 'xs' }"}},
+		{content: "apply spaceless|upper", name: "apply", expressions: []string{"spaceless|upper"}},
+		{content: "autoescape false", name: "autoescape", expressions: []string{"false"}},
+		{content: "block content", name: "block"},
+		{content: "endblock", name: "endblock"},
+	}
+
+	for _, tc := range testcases {
+		tag, err := ParseTag(tc.content)
+		assert.NoError(t, err, tc.content)
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, tc.name, tag.Name)
+
+		var expressions []string
+		for _, expr := range tag.Expressions {
+			expressions = append(expressions, expr.Dump())
+		}
+		assert.Equal(t, tc.expressions, expressions, tc.content)
+	}
+}
+
+func TestPrintNodeParseExpression(t *testing.T) {
+	nodes, err := ParseTemplate(`<div>{{ product.name|striptags }}</div>`)
+	assert.NoError(t, err)
+
+	printNodes := nodes.Find(func(node Node) bool {
+		_, ok := node.(*PrintNode)
+		return ok
+	})
+	assert.Len(t, printNodes, 1)
+
+	expr, err := printNodes[0].(*PrintNode).ParseExpression()
+	assert.NoError(t, err)
+	assert.Equal(t, "striptags", expr.(*FilterExpression).Name)
+}
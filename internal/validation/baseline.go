@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// DefaultBaselineFile is the baseline in the root of the extension or project, used when no --baseline file is passed
+const DefaultBaselineFile = ".onlishop-validation-baseline.json"
+
+// Baseline contains the known results which should not fail the validation anymore
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a known result. The line is not part of the entry, so unrelated changes in the file do not invalidate it.
+type BaselineEntry struct {
+	Identifier  string `json:"identifier"`
+	Path        string `json:"path"`
+	Fingerprint string `json:"fingerprint"`
+	// The message is only stored to make the baseline readable, matching uses the fingerprint
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// MessageFingerprint returns the md5 of the message of the result
+func MessageFingerprint(r CheckResult) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(r.Message)))
+}
+
+func baselineKey(identifier, path, fingerprint string) string {
+	return identifier + "\x00" + path + "\x00" + fingerprint
+}
+
+// NewBaseline creates a baseline of the given results, same results are merged into one entry with a count
+func NewBaseline(results []CheckResult) *Baseline {
+	entries := make(map[string]*BaselineEntry)
+
+	for _, r := range results {
+		fingerprint := MessageFingerprint(r)
+		key := baselineKey(r.Identifier, r.Path, fingerprint)
+
+		if entry, ok := entries[key]; ok {
+			entry.Count++
+			continue
+		}
+
+		entries[key] = &BaselineEntry{
+			Identifier:  r.Identifier,
+			Path:        r.Path,
+			Fingerprint: fingerprint,
+			Message:     r.Message,
+			Count:       1,
+		}
+	}
+
+	baseline := &Baseline{Entries: make([]BaselineEntry, 0, len(entries))}
+	for _, entry := range entries {
+		baseline.Entries = append(baseline.Entries, *entry)
+	}
+
+	// Sort entries for a stable file content
+	sort.Slice(baseline.Entries, func(i, j int) bool {
+		a, b := baseline.Entries[i], baseline.Entries[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Identifier != b.Identifier {
+			return a.Identifier < b.Identifier
+		}
+		return a.Message < b.Message
+	})
+
+	return baseline
+}
+
+// ReadBaseline reads a baseline file written by WriteBaseline
+func ReadBaseline(file string) (*Baseline, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return nil, fmt.Errorf("cannot parse baseline %s: %w", file, err)
+	}
+
+	return &baseline, nil
+}
+
+// WriteBaseline writes the baseline to the given file
+func WriteBaseline(file string, baseline *Baseline) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(file, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Apply returns the results which are not part of the baseline and the baseline entries which no longer occur
+func (b *Baseline) Apply(results []CheckResult) ([]CheckResult, []BaselineEntry) {
+	remaining := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		remaining[baselineKey(entry.Identifier, entry.Path, entry.Fingerprint)] += max(entry.Count, 1)
+	}
+
+	newResults := make([]CheckResult, 0)
+	for _, r := range results {
+		key := baselineKey(r.Identifier, r.Path, MessageFingerprint(r))
+
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+
+		newResults = append(newResults, r)
+	}
+
+	stale := make([]BaselineEntry, 0)
+	for _, entry := range b.Entries {
+		key := baselineKey(entry.Identifier, entry.Path, entry.Fingerprint)
+
+		if remaining[key] > 0 {
+			entry.Count = remaining[key]
+			stale = append(stale, entry)
+			// Entries with the same key are merged, report them only once
+			remaining[key] = 0
+		}
+	}
+
+	return newResults, stale
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaselineApply(t *testing.T) {
+	known := []CheckResult{
+		{Path: "src/Foo.php", Line: 10, Identifier: "phpstan.missingType", Message: "Missing type", Severity: SeverityError},
+		{Path: "src/Foo.php", Line: 20, Identifier: "phpstan.missingType", Message: "Missing type", Severity: SeverityError},
+		{Path: "src/Bar.php", Line: 5, Identifier: "phpstan.unused", Message: "Unused variable", Severity: SeverityWarning},
+	}
+
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	assert.NoError(t, WriteBaseline(file, NewBaseline(known)))
+
+	baseline, err := ReadBaseline(file)
+	assert.NoError(t, err)
+	assert.Len(t, baseline.Entries, 2)
+	assert.Equal(t, "src/Bar.php", baseline.Entries[0].Path)
+	assert.Equal(t, 2, baseline.Entries[1].Count)
+
+	current := []CheckResult{
+		// Moved to another line, still known
+		{Path: "src/Foo.php", Line: 12, Identifier: "phpstan.missingType", Message: "Missing type", Severity: SeverityError},
+		{Path: "src/Foo.php", Line: 22, Identifier: "phpstan.missingType", Message: "Missing type", Severity: SeverityError},
+		// A third occurrence is new
+		{Path: "src/Foo.php", Line: 30, Identifier: "phpstan.missingType", Message: "Missing type", Severity: SeverityError},
+		{Path: "src/Foo.php", Line: 40, Identifier: "phpstan.missingType", Message: "Other message", Severity: SeverityError},
+	}
+
+	newResults, stale := baseline.Apply(current)
+
+	assert.Len(t, newResults, 2)
+	assert.Equal(t, 30, newResults[0].Line)
+	assert.Equal(t, "Other message", newResults[1].Message)
+
+	assert.Len(t, stale, 1)
+	assert.Equal(t, "phpstan.unused", stale[0].Identifier)
+	assert.Equal(t, 1, stale[0].Count)
+}
+
+func TestReadBaselineMissingFile(t *testing.T) {
+	_, err := ReadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
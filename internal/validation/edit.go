@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// CheckPosition is a 1-based position in a file
+type CheckPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// CheckEdit replaces the text between Start (inclusive) and End (exclusive) with NewText
+type CheckEdit struct {
+	Start   CheckPosition `json:"start"`
+	End     CheckPosition `json:"end"`
+	NewText string        `json:"newText"`
+}
+
+// IsLineEdit reports whether the edit replaces complete lines
+func (e CheckEdit) IsLineEdit() bool {
+	return e.Start.Column == 1 && e.End.Column == 1 && e.End.Line > e.Start.Line
+}
+
+// ContainsLine reports whether the line is part of the replaced text
+func (e CheckEdit) ContainsLine(line int) bool {
+	return line >= e.Start.Line && (line < e.End.Line || (line == e.End.Line && e.End.Column > 1))
+}
+
+// LineEdits computes the edits which turn the original content into the updated content, every changed block of lines is one edit
+func LineEdits(original, updated string) []CheckEdit {
+	originalLines := splitLines(original)
+	updatedLines := splitLines(updated)
+
+	matcher := difflib.NewMatcher(originalLines, updatedLines)
+
+	var edits []CheckEdit
+
+	for _, op := range matcher.GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+
+		edits = append(edits, CheckEdit{
+			Start:   CheckPosition{Line: op.I1 + 1, Column: 1},
+			End:     CheckPosition{Line: op.I2 + 1, Column: 1},
+			NewText: strings.Join(updatedLines[op.J1:op.J2], ""),
+		})
+	}
+
+	return edits
+}
+
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineEdits(t *testing.T) {
+	original := "<div>\n    <sw-alert>\n        Hi\n    </sw-alert>\n</div>\n"
+	updated := "<div>\n    <mt-banner>\n        Hi\n    </mt-banner>\n</div>\n"
+
+	edits := LineEdits(original, updated)
+
+	assert.Equal(t, []CheckEdit{
+		{Start: CheckPosition{Line: 2, Column: 1}, End: CheckPosition{Line: 3, Column: 1}, NewText: "    <mt-banner>\n"},
+		{Start: CheckPosition{Line: 4, Column: 1}, End: CheckPosition{Line: 5, Column: 1}, NewText: "    </mt-banner>\n"},
+	}, edits)
+
+	assert.True(t, edits[0].IsLineEdit())
+	assert.True(t, edits[0].ContainsLine(2))
+	assert.False(t, edits[0].ContainsLine(3))
+
+	assert.Empty(t, LineEdits(original, original))
+}
+
+func TestGitHubReportSuggestions(t *testing.T) {
+	check := &testCheck{Results: []CheckResult{
+		{
+			Path:       "src/index.html.twig",
+			Line:       2,
+			Identifier: "sw-alert",
+			Message:    "sw-alert is removed",
+			Severity:   SeverityWarning,
+			Edits: []CheckEdit{
+				{Start: CheckPosition{Line: 2, Column: 1}, End: CheckPosition{Line: 3, Column: 1}, NewText: "<mt-banner>"},
+				{Start: CheckPosition{Line: 2, Column: 3}, End: CheckPosition{Line: 2, Column: 5}, NewText: "mt"},
+			},
+		},
+	}}
+
+	output := captureOutput(func() {
+		assert.NoError(t, doGitHubReport(check))
+	})
+
+	assert.Equal(t, "::warning file=src/index.html.twig,line=2,title=sw-alert::sw-alert is removed%0A%0ASuggested fix, replace lines 2-2 with:%0A<mt-banner>\n", output)
+}
+
+func TestGitHubReportSuggestionsRemove(t *testing.T) {
+	check := &testCheck{Results: []CheckResult{
+		{
+			Path:       "src/index.html.twig",
+			Line:       4,
+			Identifier: "sw-alert",
+			Message:    "sw-alert is removed",
+			Severity:   SeverityError,
+			Edits: []CheckEdit{
+				{Start: CheckPosition{Line: 4, Column: 1}, End: CheckPosition{Line: 6, Column: 1}},
+			},
+		},
+	}}
+
+	output := captureOutput(func() {
+		assert.NoError(t, doGitHubReport(check))
+	})
+
+	assert.Equal(t, "::error file=src/index.html.twig,line=4,title=sw-alert::sw-alert is removed%0A%0ASuggested fix, remove lines 4-5\n", output)
+}
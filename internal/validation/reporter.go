@@ -6,6 +6,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -48,6 +49,10 @@ func DoCheckReport(result Check, reportingFormat string) error {
 		if err := doJUnitReport(result); err != nil {
 			return err
 		}
+	case "sarif":
+		if err := doSarifReport(result); err != nil {
+			return err
+		}
 	}
 
 	if result.HasErrors() {
@@ -157,7 +162,25 @@ func doGitHubReport(result Check) error {
 			line = fmt.Sprintf(",line=%d", r.Line)
 		}
 
-		message := strings.ReplaceAll(r.Message, "\n", "%0A")
+		message := r.Message
+
+		// Workflow annotations are rendered as plain text, so suggestion blocks would show up verbatim.
+		// Only describe whole line replacements, partial edits are hard to read without the original line.
+		for _, edit := range r.Edits {
+			if !edit.IsLineEdit() {
+				continue
+			}
+
+			newText := strings.TrimSuffix(edit.NewText, "\n")
+
+			if newText == "" {
+				message += fmt.Sprintf("\n\nSuggested fix, remove lines %d-%d", edit.Start.Line, edit.End.Line-1)
+			} else {
+				message += fmt.Sprintf("\n\nSuggested fix, replace lines %d-%d with:\n%s", edit.Start.Line, edit.End.Line-1, newText)
+			}
+		}
+
+		message = strings.ReplaceAll(message, "\n", "%0A")
 		message = strings.ReplaceAll(message, "\r", "%0D")
 
 		fmt.Printf("::%s file=%s%s,title=%s::%s\n", level, file, line, r.Identifier, message)
@@ -391,3 +414,159 @@ func doJUnitReport(result Check) error {
 	encoder.Indent("", "  ")
 	return encoder.Encode(suite)
 }
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// Results of unknown tools are reported under this tool name
+	sarifDefaultTool = "onlishop-cli"
+)
+
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID string `json:"id"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func doSarifReport(result Check) error {
+	// Sort results for deterministic output
+	results := result.GetResults()
+	sort.Slice(results, func(i, j int) bool {
+		// Sort by path first, then by line number, then by identifier, then by message
+		if results[i].Path != results[j].Path {
+			return results[i].Path < results[j].Path
+		}
+		if results[i].Line != results[j].Line {
+			return results[i].Line < results[j].Line
+		}
+		if results[i].Identifier != results[j].Identifier {
+			return results[i].Identifier < results[j].Identifier
+		}
+		return results[i].Message < results[j].Message
+	})
+
+	// Group results by tool, each tool is its own run
+	toolResults := make(map[string][]CheckResult)
+	for _, r := range results {
+		tool := r.Tool
+		if tool == "" {
+			tool = sarifDefaultTool
+		}
+
+		toolResults[tool] = append(toolResults[tool], r)
+	}
+
+	var sortedTools []string
+	for tool := range toolResults {
+		sortedTools = append(sortedTools, tool)
+	}
+	sort.Strings(sortedTools)
+
+	log := SarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []SarifRun{},
+	}
+
+	for _, tool := range sortedTools {
+		run := SarifRun{
+			Tool:    SarifTool{Driver: SarifDriver{Name: tool, Rules: []SarifRule{}}},
+			Results: []SarifResult{},
+		}
+
+		ruleIndexes := make(map[string]int)
+
+		for _, r := range toolResults[tool] {
+			ruleIndex, ok := ruleIndexes[r.Identifier]
+			if !ok {
+				ruleIndex = len(run.Tool.Driver.Rules)
+				ruleIndexes[r.Identifier] = ruleIndex
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SarifRule{ID: r.Identifier})
+			}
+
+			level := "note"
+			switch r.Severity {
+			case SeverityError:
+				level = "error"
+			case SeverityWarning:
+				level = "warning"
+			}
+
+			sarifResult := SarifResult{
+				RuleID:    r.Identifier,
+				RuleIndex: ruleIndex,
+				Level:     level,
+				Message:   SarifMessage{Text: r.Message},
+			}
+
+			if r.Path != "" {
+				location := SarifLocation{
+					PhysicalLocation: SarifPhysicalLocation{
+						ArtifactLocation: SarifArtifactLocation{URI: filepath.ToSlash(r.Path)},
+					},
+				}
+
+				if r.Line > 0 {
+					location.PhysicalLocation.Region = &SarifRegion{StartLine: r.Line}
+				}
+
+				sarifResult.Locations = []SarifLocation{location}
+			}
+
+			run.Results = append(run.Results, sarifResult)
+		}
+
+		log.Runs = append(log.Runs, run)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -207,6 +207,66 @@ func TestGitLabReport(t *testing.T) {
 	assert.NotEqual(t, issue1.Fingerprint, issue2.Fingerprint)
 }
 
+func TestSarifReport(t *testing.T) {
+	testResults := []CheckResult{
+		{
+			Path:       "src/utils.js",
+			Line:       15,
+			Identifier: "eslint/semi",
+			Message:    "Missing semicolon",
+			Severity:   SeverityError,
+			Tool:       "eslint",
+		},
+		{
+			Path:       "src/index.js",
+			Line:       42,
+			Identifier: "eslint/no-unused-vars",
+			Message:    "'unused' is assigned a value but never used.",
+			Severity:   SeverityWarning,
+			Tool:       "eslint",
+		},
+		{
+			Path:       "src/Foo.php",
+			Identifier: "phpstan/error",
+			Message:    "Something went wrong",
+			Severity:   SeverityError,
+			Tool:       "phpstan",
+		},
+		{
+			Identifier: "composer.missing",
+			Message:    "No composer.json",
+			Severity:   "info",
+		},
+	}
+
+	check := &testCheck{Results: testResults}
+
+	output := captureOutput(func() {
+		assert.NoError(t, doSarifReport(check))
+	})
+
+	var log SarifLog
+	assert.NoError(t, json.Unmarshal([]byte(output), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 3)
+
+	assert.Equal(t, "eslint", log.Runs[0].Tool.Driver.Name)
+	assert.Equal(t, []SarifRule{{ID: "eslint/no-unused-vars"}, {ID: "eslint/semi"}}, log.Runs[0].Tool.Driver.Rules)
+	assert.Len(t, log.Runs[0].Results, 2)
+	assert.Equal(t, "warning", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "src/index.js", log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 42, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "error", log.Runs[0].Results[1].Level)
+	assert.Equal(t, 1, log.Runs[0].Results[1].RuleIndex)
+
+	assert.Equal(t, "onlishop-cli", log.Runs[1].Tool.Driver.Name)
+	assert.Equal(t, "note", log.Runs[1].Results[0].Level)
+	assert.Empty(t, log.Runs[1].Results[0].Locations)
+
+	assert.Equal(t, "phpstan", log.Runs[2].Tool.Driver.Name)
+	assert.Nil(t, log.Runs[2].Results[0].Locations[0].PhysicalLocation.Region)
+}
+
 func TestGitLabReportIsDeterministic(t *testing.T) {
 	testResults := []CheckResult{
 		{
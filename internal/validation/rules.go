@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// RuleConfig configures a single rule of the twig linters
+type RuleConfig struct {
+	// The ID of the rule, e.g. twig-linter/image-alt
+	Rule string `yaml:"rule"`
+	// Set to false to disable the rule
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Overrides the severity of the results of the rule (error, warning)
+	Severity string `yaml:"severity,omitempty" jsonschema:"enum=error,enum=warning"`
+	// Limits the rule to these paths relative to the root, glob patterns or directories
+	Paths []string `yaml:"paths,omitempty"`
+}
+
+type RuleConfigs []RuleConfig
+
+// Get returns the configuration of the rule, the last matching entry wins
+func (r RuleConfigs) Get(id string) RuleConfig {
+	config := RuleConfig{Rule: id}
+
+	for _, rule := range r {
+		if rule.Rule != id {
+			continue
+		}
+
+		if rule.Enabled != nil {
+			config.Enabled = rule.Enabled
+		}
+
+		if rule.Severity != "" {
+			config.Severity = rule.Severity
+		}
+
+		if len(rule.Paths) > 0 {
+			config.Paths = rule.Paths
+		}
+	}
+
+	return config
+}
+
+// IsEnabled reports whether the rule should run, rules are enabled by default
+func (c RuleConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// AppliesTo reports whether the rule is enabled for the file
+func (c RuleConfig) AppliesTo(file string) bool {
+	if !c.IsEnabled() {
+		return false
+	}
+
+	if len(c.Paths) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.Paths {
+		if matched, _ := path.Match(pattern, file); matched {
+			return true
+		}
+
+		if strings.HasPrefix(file, strings.TrimSuffix(pattern, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply overrides the severity of the results
+func (c RuleConfig) Apply(results []CheckResult) []CheckResult {
+	if c.Severity == "" {
+		return results
+	}
+
+	for i := range results {
+		results[i].Severity = c.Severity
+	}
+
+	return results
+}
+
+// Validate checks that all configured rules exist and use a valid severity
+func (r RuleConfigs) Validate(knownRules []string) error {
+	for _, rule := range r {
+		if !slices.Contains(knownRules, rule.Rule) {
+			return fmt.Errorf("unknown validation rule %q, possible rules: %s", rule.Rule, strings.Join(knownRules, ", "))
+		}
+
+		if rule.Severity != "" && rule.Severity != SeverityError && rule.Severity != SeverityWarning {
+			return fmt.Errorf("invalid severity %q for validation rule %q, must be either 'error' or 'warning'", rule.Severity, rule.Rule)
+		}
+	}
+
+	return nil
+}
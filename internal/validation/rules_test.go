@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleConfigs(t *testing.T) {
+	disabled := false
+
+	rules := RuleConfigs{
+		{Rule: "twig-linter/image-alt", Severity: SeverityError},
+		{Rule: "twig-linter/inline-style", Enabled: &disabled},
+		{Rule: "twig-linter/external-link", Paths: []string{"src/Resources/views/storefront", "*.html.twig"}},
+	}
+
+	imageAlt := rules.Get("twig-linter/image-alt")
+	assert.True(t, imageAlt.AppliesTo("src/Resources/views/base.html.twig"))
+	assert.Equal(t, []CheckResult{{Severity: SeverityError}}, imageAlt.Apply([]CheckResult{{Severity: SeverityWarning}}))
+
+	assert.False(t, rules.Get("twig-linter/inline-style").AppliesTo("src/Resources/views/base.html.twig"))
+
+	link := rules.Get("twig-linter/external-link")
+	assert.True(t, link.AppliesTo("src/Resources/views/storefront/page/index.html.twig"))
+	assert.True(t, link.AppliesTo("base.html.twig"))
+	assert.False(t, link.AppliesTo("src/Resources/views/email/index.html.twig"))
+
+	// Unconfigured rules are enabled everywhere
+	assert.True(t, rules.Get("admintwiglinter/sw-alert").AppliesTo("src/index.html.twig"))
+}
+
+func TestRuleConfigsValidate(t *testing.T) {
+	known := []string{"twig-linter/image-alt"}
+
+	assert.NoError(t, RuleConfigs{{Rule: "twig-linter/image-alt", Severity: SeverityWarning}}.Validate(known))
+	assert.ErrorContains(t, RuleConfigs{{Rule: "twig-linter/unknown"}}.Validate(known), "unknown validation rule")
+	assert.ErrorContains(t, RuleConfigs{{Rule: "twig-linter/image-alt", Severity: "info"}}.Validate(known), "invalid severity")
+}
@@ -19,6 +19,10 @@ type CheckResult struct {
 	Severity string `json:"severity"`
 
 	Identifier string `json:"identifier"`
+	// The name of the tool which reported the issue
+	Tool string `json:"tool,omitempty"`
+	// Edits which fix the issue when applied to the file
+	Edits []CheckEdit `json:"edits,omitempty"`
 }
 
 // ToolConfigIgnore represents a configuration item to ignore during validation
@@ -13,6 +13,7 @@ import (
 	"github.com/onlishop/onlishop-cli/internal/html"
 	"github.com/onlishop/onlishop-cli/internal/validation"
 	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+	_ "github.com/onlishop/onlishop-cli/internal/verifier/twiglinter/admintwiglinter"
 	"github.com/onlishop/onlishop-cli/logging"
 )
 
@@ -23,7 +24,7 @@ func (a AdminTwigLinter) Name() string {
 }
 
 func (a AdminTwigLinter) Check(ctx context.Context, check *Check, config ToolConfig) error {
-	fixers := twiglinter.GetAdministrationFixers(version.Must(version.NewVersion(config.MinOnlishopVersion)))
+	rules := twiglinter.GetAdministrationRules(version.Must(version.NewVersion(config.MinOnlishopVersion)))
 
 	for _, p := range config.AdminDirectories {
 		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
@@ -39,6 +40,10 @@ func (a AdminTwigLinter) Check(ctx context.Context, check *Check, config ToolCon
 				return nil
 			}
 
+			if !config.IsChanged(path) {
+				return nil
+			}
+
 			file, err := os.ReadFile(path)
 			if err != nil {
 				return err
@@ -49,14 +54,34 @@ func (a AdminTwigLinter) Check(ctx context.Context, check *Check, config ToolCon
 				return fmt.Errorf("failed to parse %s: %w", path, err)
 			}
 
-			for _, fixer := range fixers {
-				for _, message := range fixer.Check(parsed.Nodes) {
+			relPath := strings.TrimPrefix(strings.TrimPrefix(path, "/private"), config.RootDir+"/")
+
+			for _, rule := range rules {
+				ruleConfig := config.ValidationRules.Get(rule.ID)
+				if !ruleConfig.AppliesTo(relPath) {
+					continue
+				}
+
+				messages := ruleConfig.Apply(rule.Fixer.Check(parsed.Nodes))
+				if len(messages) == 0 {
+					continue
+				}
+
+				edits, err := twiglinter.FixEdits(rule.Fixer, string(file), html.NewAdminParser)
+				if err != nil {
+					return fmt.Errorf("failed to fix %s: %w", path, err)
+				}
+
+				twiglinter.AttachEdits(messages, edits)
+
+				for _, message := range messages {
 					check.AddResult(validation.CheckResult{
 						Message:    message.Message,
-						Path:       strings.TrimPrefix(strings.TrimPrefix(path, "/private"), config.RootDir+"/"),
-						Line:       0,
+						Path:       relPath,
+						Line:       message.Line,
 						Severity:   message.Severity,
 						Identifier: fmt.Sprintf("admintwiglinter/%s", message.Identifier),
+						Edits:      message.Edits,
 					})
 				}
 			}
@@ -72,7 +97,7 @@ func (a AdminTwigLinter) Check(ctx context.Context, check *Check, config ToolCon
 }
 
 func (a AdminTwigLinter) Fix(ctx context.Context, config ToolConfig) error {
-	fixers := twiglinter.GetAdministrationFixers(version.Must(version.NewVersion(config.MinOnlishopVersion)))
+	rules := twiglinter.GetAdministrationRules(version.Must(version.NewVersion(config.MinOnlishopVersion)))
 
 	for _, p := range config.AdminDirectories {
 		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
@@ -98,8 +123,14 @@ func (a AdminTwigLinter) Fix(ctx context.Context, config ToolConfig) error {
 				return err
 			}
 
-			for _, fixer := range fixers {
-				if err := fixer.Fix(parsed.Nodes); err != nil {
+			relPath := strings.TrimPrefix(strings.TrimPrefix(path, "/private"), config.RootDir+"/")
+
+			for _, rule := range rules {
+				if !config.ValidationRules.Get(rule.ID).AppliesTo(relPath) {
+					continue
+				}
+
+				if err := rule.Fixer.Fix(parsed.Nodes); err != nil {
 					return err
 				}
 			}
@@ -0,0 +1,19 @@
+package verifier
+
+import (
+	"testing"
+
+	"github.com/shyim/go-version"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestAdministrationRulesAreRegistered(t *testing.T) {
+	assert.Contains(t, twiglinter.RuleIDs(), "admintwiglinter/sw-alert")
+	assert.Contains(t, twiglinter.RuleIDs(), "twig-linter/unsafe-output")
+	assert.NotEmpty(t, twiglinter.GetAdministrationRules(version.Must(version.NewVersion("6.7.0.0"))))
+
+	assert.NoError(t, validation.RuleConfigs{{Rule: "admintwiglinter/sw-alert", Severity: validation.SeverityWarning}}.Validate(twiglinter.RuleIDs()))
+}
@@ -0,0 +1,69 @@
+package verifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+// ResolveBaselineFile returns the baseline file to read or, with generate, to write. Without a configured file the
+// default baseline in the root directory is used, for reading only when it exists.
+func ResolveBaselineFile(rootDir, file string, generate bool) string {
+	if file != "" {
+		return file
+	}
+
+	defaultFile := filepath.Join(rootDir, validation.DefaultBaselineFile)
+
+	if generate {
+		return defaultFile
+	}
+
+	if _, err := os.Stat(defaultFile); err != nil {
+		return ""
+	}
+
+	return defaultFile
+}
+
+// GenerateBaseline writes all current results of the check into the baseline file
+func GenerateBaseline(ctx context.Context, check *Check, file string) error {
+	baseline := validation.NewBaseline(check.GetResults())
+
+	if err := validation.WriteBaseline(file, baseline); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Infof("Wrote %d results into the baseline %s", len(check.GetResults()), file)
+
+	return nil
+}
+
+// ApplyBaseline removes all results known by the baseline file from the check and reports entries which no longer occur.
+// With --changed-since only the entries of the changed files are reported, the other files were not checked.
+func ApplyBaseline(ctx context.Context, check *Check, file string, config ToolConfig) error {
+	baseline, err := validation.ReadBaseline(file)
+	if err != nil {
+		return err
+	}
+
+	_, stale := check.RemoveBaselined(baseline)
+
+	stale = slices.DeleteFunc(stale, func(entry validation.BaselineEntry) bool {
+		return entry.Path != "" && !config.IsChanged(entry.Path)
+	})
+
+	for _, entry := range stale {
+		logging.FromContext(ctx).Warnf("Baseline entry %s in %s no longer occurs (%dx): %s", entry.Identifier, entry.Path, entry.Count, entry.Message)
+	}
+
+	if len(stale) > 0 {
+		logging.FromContext(ctx).Warnf("Regenerate the baseline with --generate-baseline to remove %d fixed entries", len(stale))
+	}
+
+	return nil
+}
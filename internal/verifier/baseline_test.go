@@ -0,0 +1,51 @@
+package verifier
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+func TestApplyBaselineReportsOnlyStaleEntriesOfChangedFiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "baseline.json")
+
+	require.NoError(t, validation.WriteBaseline(file, validation.NewBaseline([]validation.CheckResult{
+		{Identifier: "phpstan/error", Path: "src/Changed.php", Message: "fixed"},
+		{Identifier: "phpstan/error", Path: "src/Unchanged.php", Message: "still there"},
+	})))
+
+	core, logs := observer.New(zap.WarnLevel)
+	ctx := logging.WithLogger(t.Context(), zap.New(core).Sugar())
+
+	config := ToolConfig{RootDir: "/ext", ChangedFiles: []string{"src/Changed.php"}}
+	assert.NoError(t, ApplyBaseline(ctx, NewCheck(), file, config))
+
+	messages := make([]string, 0)
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+
+	assert.Len(t, messages, 2)
+	assert.Contains(t, messages[0], "src/Changed.php")
+	assert.NotContains(t, messages[0], "src/Unchanged.php")
+}
+
+func TestResolveBaselineFile(t *testing.T) {
+	root := t.TempDir()
+	defaultFile := filepath.Join(root, validation.DefaultBaselineFile)
+
+	assert.Equal(t, "custom.json", ResolveBaselineFile(root, "custom.json", false))
+	assert.Equal(t, "", ResolveBaselineFile(root, "", false))
+	assert.Equal(t, defaultFile, ResolveBaselineFile(root, "", true))
+
+	require.NoError(t, validation.WriteBaseline(defaultFile, validation.NewBaseline(nil)))
+
+	assert.Equal(t, defaultFile, ResolveBaselineFile(root, "", false))
+}
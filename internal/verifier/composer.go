@@ -7,11 +7,36 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"sync"
 
 	"github.com/onlishop/onlishop-cli/internal/packagist"
 )
 
+// composerLocks serializes the composer install per root directory, the tools run in parallel and share the vendor folder
+var (
+	composerLocks   = map[string]*sync.Mutex{}
+	composerLocksMu sync.Mutex
+)
+
+func composerLock(rootDir string) *sync.Mutex {
+	composerLocksMu.Lock()
+	defer composerLocksMu.Unlock()
+
+	rootDir = filepath.Clean(rootDir)
+
+	if _, ok := composerLocks[rootDir]; !ok {
+		composerLocks[rootDir] = &sync.Mutex{}
+	}
+
+	return composerLocks[rootDir]
+}
+
 func installComposerDeps(ctx context.Context, rootDir string, checkAgainst string) error {
+	lock := composerLock(rootDir)
+	lock.Lock()
+	defer lock.Unlock()
+
 	suggets := getComposerSuggets(rootDir)
 
 	if _, err := os.Stat(path.Join(rootDir, "vendor")); os.IsNotExist(err) {
@@ -0,0 +1,12 @@
+package verifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposerLockPerRootDir(t *testing.T) {
+	assert.Same(t, composerLock("/tmp/project"), composerLock("/tmp/project/"))
+	assert.NotSame(t, composerLock("/tmp/project"), composerLock("/tmp/other"))
+}
@@ -58,9 +58,18 @@ func (e Eslint) Check(ctx context.Context, check *Check, config ToolConfig) erro
 	for _, p := range paths {
 		p := p
 
+		var files []string
+
+		if config.OnlyChangedFiles() {
+			files = config.ChangedFilesIn(p, ".js", ".ts", ".vue")
+
+			if len(files) == 0 {
+				continue
+			}
+		}
+
 		gr.Go(func() error {
-			eslint := exec.CommandContext(ctx,
-				"node",
+			args := []string{
 				path.Join(config.ToolDirectory, "js", "node_modules", ".bin", "eslint"),
 				"--format=json",
 				"--config", path.Join(config.ToolDirectory, "js", "configs", fmt.Sprintf("eslint.config.%s.mjs", path.Base(p))),
@@ -69,7 +78,9 @@ func (e Eslint) Check(ctx context.Context, check *Check, config ToolConfig) erro
 				"--ignore-pattern", "test/e2e/**",
 				"--ignore-pattern", "**/jest.config.js",
 				"--no-error-on-unmatched-pattern",
-			)
+			}
+
+			eslint := exec.CommandContext(ctx, "node", append(args, files...)...)
 			eslint.Dir = p
 			eslint.Env = env
 
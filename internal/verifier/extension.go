@@ -10,6 +10,7 @@ import (
 
 	"github.com/onlishop/onlishop-cli/extension"
 	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
 )
 
 func ConvertExtensionToToolConfig(ext extension.Extension) (*ToolConfig, error) {
@@ -27,12 +28,17 @@ func ConvertExtensionToToolConfig(ext extension.Extension) (*ToolConfig, error)
 		ToolDirectory:         GetToolDirectory(),
 		Extension:             ext,
 		ValidationIgnores:     ignores,
+		ValidationRules:       ext.GetExtensionConfig().Validation.Rules,
 		RootDir:               ext.GetPath(),
 		SourceDirectories:     ext.GetSourceDirs(),
 		AdminDirectories:      getAdminFolders(ext),
 		StorefrontDirectories: getStorefrontFolders(ext),
 	}
 
+	if err := cfg.ValidationRules.Validate(twiglinter.RuleIDs()); err != nil {
+		return nil, err
+	}
+
 	constraint, err := ext.GetOnlishopVersionConstraint()
 	if err != nil {
 		return nil, err
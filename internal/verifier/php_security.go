@@ -0,0 +1,250 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onlishop/onlishop-cli/internal/validation"
+)
+
+var (
+	phpEvalRegExp          = regexp.MustCompile(`(?:^|[^\w$>:])\\?(eval)\s*\(`)
+	phpShellRegExp         = regexp.MustCompile(`(?:^|[^\w$>:])\\?(exec|shell_exec|system|passthru|proc_open|popen)\s*\(`)
+	phpFunctionDefRegExp   = regexp.MustCompile(`function\s+$`)
+	phpUnserializeRegExp   = regexp.MustCompile(`(?:^|[^\w$>:])\\?(unserialize)\s*\(`)
+	phpRequestDataRegExp   = regexp.MustCompile(`\$_(GET|POST|REQUEST|COOKIE|SERVER|FILES)\b|\$request\b|->(request|query|cookies|headers)->|getContent\(`)
+	phpDbalCallRegExp      = regexp.MustCompile(`->(executeQuery|executeStatement|executeUpdate|prepare|query|exec|fetchOne|fetchAssociative|fetchNumeric|fetchFirstColumn|fetchAllAssociative|fetchAllNumeric|fetchAllKeyValue|fetchAllAssociativeIndexed)\s*\(`)
+	phpConcatRegExp        = regexp.MustCompile(`\.\s*\$\w|\$\w+(?:->\w+|\[[^\]]*\])*\s*\.[^=]|\bsprintf\s*\(`)
+	phpInterpolationRegExp = regexp.MustCompile(`"[^"]*(?:\$\w|\{\$)[^"]*"`)
+	phpCsrfDisabledRegExp  = regexp.MustCompile(`["']?csrf_protect(?:ed|ion)["']?\s*(?:=>|=|:)\s*false`)
+)
+
+// PHPSecurity looks for dangerous patterns in the PHP sources, which are rejected by the store code review.
+type PHPSecurity struct{}
+
+func (p PHPSecurity) Name() string {
+	return "php-security"
+}
+
+func (p PHPSecurity) Check(ctx context.Context, check *Check, config ToolConfig) error {
+	for _, sourceDirectory := range config.SourceDirectories {
+		if _, err := os.Stat(sourceDirectory); err != nil {
+			continue
+		}
+
+		err := filepath.WalkDir(sourceDirectory, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if d.Name() == "vendor" || d.Name() == "node_modules" {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if filepath.Ext(path) != ".php" {
+				return nil
+			}
+
+			if !config.IsChanged(path) {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			relPath := strings.TrimPrefix(strings.TrimPrefix(path, "/private"), config.RootDir+"/")
+
+			for _, result := range scanPHPSecurity(string(content)) {
+				result.Path = relPath
+				check.AddResult(result)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p PHPSecurity) Fix(ctx context.Context, config ToolConfig) error {
+	return nil
+}
+
+func (p PHPSecurity) Format(ctx context.Context, config ToolConfig, dryRun bool) error {
+	return nil
+}
+
+// scanPHPSecurity returns the findings of one PHP file without a path.
+func scanPHPSecurity(content string) []validation.CheckResult {
+	code := stripPHPComments(content, false)
+	// Function calls are searched without string contents, so URLs or messages do not match
+	calls := stripPHPComments(content, true)
+	results := make([]validation.CheckResult, 0)
+
+	add := func(offset int, identifier, message string, severity string) {
+		results = append(results, validation.CheckResult{
+			Line:       strings.Count(code[:offset], "\n") + 1,
+			Identifier: identifier,
+			Message:    message,
+			Severity:   severity,
+		})
+	}
+
+	for _, match := range phpEvalRegExp.FindAllStringSubmatchIndex(calls, -1) {
+		add(match[2], "php-security.eval", "eval() executes arbitrary code and is not allowed", validation.SeverityError)
+	}
+
+	for _, match := range phpShellRegExp.FindAllStringSubmatchIndex(calls, -1) {
+		if phpFunctionDefRegExp.MatchString(code[:match[2]]) {
+			continue
+		}
+
+		function := code[match[2]:match[3]]
+		add(match[2], "php-security.shell", fmt.Sprintf("%s() executes shell commands, use the Symfony Process component with an argument list instead", function), validation.SeverityError)
+	}
+
+	for _, match := range phpUnserializeRegExp.FindAllStringSubmatchIndex(calls, -1) {
+		if phpFunctionDefRegExp.MatchString(code[:match[2]]) {
+			continue
+		}
+
+		if phpRequestDataRegExp.MatchString(phpFirstArgument(code[match[1]:])) {
+			add(match[2], "php-security.unserialize", "unserialize() is called with request data, which allows object injection. Use json_decode instead", validation.SeverityError)
+		}
+	}
+
+	for _, match := range phpDbalCallRegExp.FindAllStringSubmatchIndex(calls, -1) {
+		sql := phpFirstArgument(code[match[1]:])
+
+		if phpConcatRegExp.MatchString(sql) || phpInterpolationRegExp.MatchString(sql) {
+			add(match[2], "php-security.sql-concatenation", fmt.Sprintf("The SQL of %s() is built from variables, use parameters to prevent SQL injections", code[match[2]:match[3]]), validation.SeverityWarning)
+		}
+	}
+
+	for _, match := range phpCsrfDisabledRegExp.FindAllStringIndex(code, -1) {
+		add(match[0], "php-security.csrf-disabled", "CSRF protection is disabled for this route", validation.SeverityWarning)
+	}
+
+	return results
+}
+
+// phpFirstArgument returns the first argument of a call, the code has to start right after the opening parenthesis.
+func phpFirstArgument(code string) string {
+	depth := 0
+	var quote byte
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return code[:i]
+			}
+
+			depth--
+		case ',':
+			if depth == 0 {
+				return code[:i]
+			}
+		}
+	}
+
+	return code
+}
+
+// stripPHPComments replaces all comments with spaces, keeping the line numbers intact.
+// With blankStrings the contents of string literals are replaced as well.
+func stripPHPComments(content string, blankStrings bool) string {
+	code := []byte(content)
+	var quote byte
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+				continue
+			}
+
+			end := i + 1
+			if c == '\\' && end < len(code) {
+				end++
+			}
+
+			if blankStrings {
+				blank(code[i:end])
+			}
+
+			i = end - 1
+
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '/' && i+1 < len(code) && code[i+1] == '*':
+			end := strings.Index(string(code[i+2:]), "*/")
+			if end == -1 {
+				end = len(code)
+			} else {
+				end += i + 4
+			}
+
+			blank(code[i:end])
+			i = end - 1
+		case (c == '/' && i+1 < len(code) && code[i+1] == '/') || (c == '#' && (i+1 >= len(code) || code[i+1] != '[')):
+			end := i
+			for end < len(code) && code[end] != '\n' {
+				end++
+			}
+
+			blank(code[i:end])
+			i = end - 1
+		}
+	}
+
+	return string(code)
+}
+
+func blank(code []byte) {
+	for i := range code {
+		if code[i] != '\n' {
+			code[i] = ' '
+		}
+	}
+}
+
+func init() {
+	AddTool(PHPSecurity{})
+}
@@ -0,0 +1,82 @@
+package verifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onlishop/onlishop-cli/internal/validation"
+)
+
+func TestScanPHPSecurity(t *testing.T) {
+	content := `<?php
+namespace Acme\Example;
+
+// eval($code) in a comment is fine
+#[Route(path: '/acme/callback', defaults: ['csrf_protected' => false])]
+class Example
+{
+    public function exec(string $command): void
+    {
+        $this->connection->executeStatement('DELETE FROM acme WHERE id = :id', ['id' => $id . '']);
+        $this->connection->fetchAllAssociative('SELECT * FROM acme WHERE name = "' . $name . '"');
+        $this->connection->executeQuery("SELECT * FROM {$table}");
+        $data = unserialize($request->get('data'));
+        $cached = unserialize($cache->get('key'));
+        eval($command);
+        $output = shell_exec('ls');
+        $this->process->exec($command);
+        echo 'http://example.com/eval(';
+    }
+}
+`
+
+	results := scanPHPSecurity(content)
+
+	found := make([]string, 0, len(results))
+	for _, result := range results {
+		found = append(found, result.Identifier)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"php-security.eval",
+		"php-security.shell",
+		"php-security.unserialize",
+		"php-security.sql-concatenation",
+		"php-security.sql-concatenation",
+		"php-security.csrf-disabled",
+	}, found)
+
+	for _, result := range results {
+		switch result.Identifier {
+		case "php-security.eval":
+			assert.Equal(t, 15, result.Line)
+			assert.Equal(t, validation.SeverityError, result.Severity)
+		case "php-security.shell":
+			assert.Equal(t, 16, result.Line)
+		case "php-security.unserialize":
+			assert.Equal(t, 13, result.Line)
+		case "php-security.csrf-disabled":
+			assert.Equal(t, 5, result.Line)
+		}
+	}
+}
+
+func TestPHPSecurityCheck(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "vendor"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "Example.php"), []byte("<?php\neval($code);\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "vendor", "Library.php"), []byte("<?php\neval($code);\n"), 0o644))
+
+	check := NewCheck()
+	require.NoError(t, PHPSecurity{}.Check(t.Context(), check, ToolConfig{RootDir: root, SourceDirectories: []string{src}}))
+
+	require.Len(t, check.Results, 1)
+	assert.Equal(t, "src/Example.php", check.Results[0].Path)
+	assert.Equal(t, 2, check.Results[0].Line)
+}
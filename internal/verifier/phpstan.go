@@ -65,7 +65,18 @@ func (p PhpStan) Check(ctx context.Context, check *Check, config ToolConfig) err
 	}
 
 	for _, sourceDirectory := range config.SourceDirectories {
-		phpstanArguments := []string{"-dmemory_limit=2G", path.Join(config.ToolDirectory, "php", "vendor", "bin", "phpstan"), "analyse", "--no-progress", "--no-interaction", "--error-format=json", sourceDirectory}
+		analysePaths := []string{sourceDirectory}
+
+		if config.OnlyChangedFiles() {
+			analysePaths = config.ChangedFilesIn(sourceDirectory, ".php")
+
+			if len(analysePaths) == 0 {
+				continue
+			}
+		}
+
+		phpstanArguments := []string{"-dmemory_limit=2G", path.Join(config.ToolDirectory, "php", "vendor", "bin", "phpstan"), "analyse", "--no-progress", "--no-interaction", "--error-format=json"}
+		phpstanArguments = append(phpstanArguments, analysePaths...)
 
 		if !p.configExists(config.RootDir) {
 			phpstanArguments = append(phpstanArguments, "--configuration", path.Join(config.ToolDirectory, "php", "configs", "phpstan.neon"))
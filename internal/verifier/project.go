@@ -14,6 +14,7 @@ import (
 
 	"github.com/onlishop/onlishop-cli/extension"
 	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
 	"github.com/onlishop/onlishop-cli/logging"
 	"github.com/onlishop/onlishop-cli/shop"
 )
@@ -179,6 +180,23 @@ func GetConfigFromProject(root string) (*ToolConfig, error) {
 		}
 	}
 
+	var validationRules validation.RuleConfigs
+
+	if shopCfg.Validation != nil {
+		for _, rule := range shopCfg.Validation.Rules {
+			validationRules = append(validationRules, validation.RuleConfig{
+				Rule:     rule.Rule,
+				Enabled:  rule.Enabled,
+				Severity: rule.Severity,
+				Paths:    rule.Paths,
+			})
+		}
+	}
+
+	if err := validationRules.Validate(twiglinter.RuleIDs()); err != nil {
+		return nil, err
+	}
+
 	toolCfg := &ToolConfig{
 		ToolDirectory:         GetToolDirectory(),
 		RootDir:               root,
@@ -186,6 +204,7 @@ func GetConfigFromProject(root string) (*ToolConfig, error) {
 		AdminDirectories:      adminDirectories,
 		StorefrontDirectories: storefrontDirectories,
 		ValidationIgnores:     validationIgnores,
+		ValidationRules:       validationRules,
 	}
 
 	if err := determineVersionRange(toolCfg, constraint); err != nil {
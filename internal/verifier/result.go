@@ -78,3 +78,30 @@ func (c *Check) RemoveByIdentifier(ignores []validation.ToolConfigIgnore) valida
 
 	return c
 }
+
+// RemoveBaselined removes the results known by the baseline and returns the baseline entries which no longer occur
+func (c *Check) RemoveBaselined(baseline *validation.Baseline) (validation.Check, []validation.BaselineEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var stale []validation.BaselineEntry
+	c.Results, stale = baseline.Apply(c.Results)
+
+	return c, stale
+}
+
+// RemoveUnchanged removes the results of files which have not been changed, results without a path are kept
+func (c *Check) RemoveUnchanged(config ToolConfig) validation.Check {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	filtered := make([]validation.CheckResult, 0)
+	for _, r := range c.Results {
+		if r.Path == "" || config.IsChanged(r.Path) {
+			filtered = append(filtered, r)
+		}
+	}
+	c.Results = filtered
+
+	return c
+}
@@ -258,3 +258,16 @@ func TestRemoveByMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveUnchanged(t *testing.T) {
+	check := NewCheck()
+	check.AddResult(validation.CheckResult{Path: "src/Foo.php", Identifier: "a"})
+	check.AddResult(validation.CheckResult{Path: "src/Bar.php", Identifier: "b"})
+	check.AddResult(validation.CheckResult{Identifier: "c"})
+
+	check.RemoveUnchanged(ToolConfig{RootDir: "/ext", ChangedFiles: []string{"src/Foo.php"}})
+
+	assert.Len(t, check.Results, 2)
+	assert.Equal(t, "a", check.Results[0].Identifier)
+	assert.Equal(t, "c", check.Results[1].Identifier)
+}
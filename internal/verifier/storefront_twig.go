@@ -23,7 +23,7 @@ func (s StorefrontTwigLinter) Name() string {
 }
 
 func (s StorefrontTwigLinter) Check(ctx context.Context, check *Check, config ToolConfig) error {
-	fixers := twiglinter.GetStorefrontFixers(version.Must(version.NewVersion(config.MinOnlishopVersion)))
+	rules := twiglinter.GetStorefrontRules(version.Must(version.NewVersion(config.MinOnlishopVersion)))
 
 	for _, p := range config.SourceDirectories {
 		twigDir := filepath.Join(p, "Resources", "views")
@@ -45,6 +45,10 @@ func (s StorefrontTwigLinter) Check(ctx context.Context, check *Check, config To
 				return nil
 			}
 
+			if !config.IsChanged(path) {
+				return nil
+			}
+
 			file, err := os.ReadFile(path)
 			if err != nil {
 				return err
@@ -65,14 +69,32 @@ func (s StorefrontTwigLinter) Check(ctx context.Context, check *Check, config To
 				return nil
 			}
 
-			for _, fixer := range fixers {
-				for _, message := range fixer.Check(parsed.Nodes) {
+			for _, rule := range rules {
+				ruleConfig := config.ValidationRules.Get(rule.ID)
+				if !ruleConfig.AppliesTo(relPath) {
+					continue
+				}
+
+				messages := ruleConfig.Apply(rule.Fixer.Check(parsed.Nodes))
+				if len(messages) == 0 {
+					continue
+				}
+
+				edits, err := twiglinter.FixEdits(rule.Fixer, string(file), html.NewStorefrontParser)
+				if err != nil {
+					return fmt.Errorf("failed to fix %s: %w", path, err)
+				}
+
+				twiglinter.AttachEdits(messages, edits)
+
+				for _, message := range messages {
 					check.AddResult(validation.CheckResult{
 						Path:       relPath,
 						Message:    message.Message,
 						Severity:   message.Severity,
 						Identifier: message.Identifier,
 						Line:       message.Line,
+						Edits:      message.Edits,
 					})
 				}
 			}
@@ -3,8 +3,12 @@ package verifier
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/onlishop/onlishop-cli/extension"
 	"github.com/onlishop/onlishop-cli/internal/validation"
 )
@@ -39,14 +43,59 @@ type ToolConfig struct {
 	SourceDirectories []string
 	// Contains a list of identifiers that are ignored
 	ValidationIgnores []validation.ToolConfigIgnore
+	// Contains the configuration of the twig linter rules
+	ValidationRules validation.RuleConfigs
 	// Contains a list of directories that are considered as admin code
 	AdminDirectories []string
 	// Contains a list of directories that are considered as storefront code
 	StorefrontDirectories []string
+	// Contains the changed files relative to RootDir, when set only these files are checked
+	ChangedFiles []string
 
 	Extension extension.Extension
 }
 
+// OnlyChangedFiles reports whether the tools should check only the changed files
+func (c ToolConfig) OnlyChangedFiles() bool {
+	return c.ChangedFiles != nil
+}
+
+// IsChanged reports whether the file should be checked, without changed files all files are checked
+func (c ToolConfig) IsChanged(file string) bool {
+	if !c.OnlyChangedFiles() {
+		return true
+	}
+
+	// Walked files contain the root directory, results are already relative to it
+	if rel, err := filepath.Rel(c.RootDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+
+	return slices.Contains(c.ChangedFiles, filepath.ToSlash(file))
+}
+
+// ChangedFilesIn returns the changed files inside the directory having one of the given extensions
+func (c ToolConfig) ChangedFilesIn(dir string, extensions ...string) []string {
+	files := make([]string, 0)
+
+	for _, file := range c.ChangedFiles {
+		fullPath := filepath.Join(c.RootDir, filepath.FromSlash(file))
+
+		rel, err := filepath.Rel(dir, fullPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		if len(extensions) > 0 && !slices.Contains(extensions, filepath.Ext(file)) {
+			continue
+		}
+
+		files = append(files, fullPath)
+	}
+
+	return files
+}
+
 type Tool interface {
 	Name() string
 	Check(ctx context.Context, check *Check, config ToolConfig) error
@@ -138,3 +187,30 @@ func (tl ToolList) PossibleString() string {
 
 	return strings.Join(possibleTools, ",")
 }
+
+// Check runs all tools in parallel and adds their results to the check, each result is tagged with the name of its tool.
+func (tl ToolList) Check(ctx context.Context, check *Check, config ToolConfig) error {
+	var gr errgroup.Group
+
+	for _, tool := range tl {
+		gr.Go(func() error {
+			toolCheck := NewCheck()
+
+			if err := tool.Check(ctx, toolCheck, config); err != nil {
+				return err
+			}
+
+			for _, result := range toolCheck.GetResults() {
+				if result.Tool == "" {
+					result.Tool = tool.Name()
+				}
+
+				check.AddResult(result)
+			}
+
+			return nil
+		})
+	}
+
+	return gr.Wait()
+}
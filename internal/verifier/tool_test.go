@@ -5,10 +5,19 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/validation"
 )
 
 type testTool struct{ name string }
 
+type reportingTestTool struct{ testTool }
+
+func (t reportingTestTool) Check(ctx context.Context, check *Check, config ToolConfig) error {
+	check.AddResult(validation.CheckResult{Identifier: t.name + "/rule", Message: "message", Severity: validation.SeverityError})
+	return nil
+}
+
 func (t testTool) Name() string                                                     { return t.name }
 func (t testTool) Check(ctx context.Context, check *Check, config ToolConfig) error { return nil }
 func (t testTool) Fix(ctx context.Context, config ToolConfig) error                 { return nil }
@@ -63,3 +72,35 @@ func TestExclude_TrimsAndIgnoresDuplicates(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"phpstan", "sw-cli"}, toolNames(res))
 }
+
+func TestToolListCheckTagsResultsWithTool(t *testing.T) {
+	tools := ToolList{reportingTestTool{testTool{"phpstan"}}, reportingTestTool{testTool{"eslint"}}, testTool{"sw-cli"}}
+
+	check := NewCheck()
+	assert.NoError(t, tools.Check(context.Background(), check, ToolConfig{}))
+
+	assert.Len(t, check.Results, 2)
+	for _, result := range check.Results {
+		assert.Equal(t, result.Tool+"/rule", result.Identifier)
+	}
+}
+
+func TestToolConfigChangedFiles(t *testing.T) {
+	config := ToolConfig{RootDir: "/ext"}
+	assert.False(t, config.OnlyChangedFiles())
+	assert.True(t, config.IsChanged("src/Foo.php"))
+
+	config.ChangedFiles = []string{"src/Foo.php", "src/Resources/app/administration/src/main.js", "src/Resources/app/administration/src/main.scss"}
+	assert.True(t, config.OnlyChangedFiles())
+	assert.True(t, config.IsChanged("src/Foo.php"))
+	assert.True(t, config.IsChanged("/ext/src/Foo.php"))
+	assert.False(t, config.IsChanged("/ext/src/Bar.php"))
+
+	assert.Equal(t, []string{"/ext/src/Foo.php"}, config.ChangedFilesIn("/ext/src", ".php"))
+	assert.Equal(t, []string{"/ext/src/Resources/app/administration/src/main.js"}, config.ChangedFilesIn("/ext/src/Resources/app/administration", ".js", ".ts"))
+	assert.Empty(t, config.ChangedFilesIn("/ext/src/Resources/app/storefront"))
+
+	config.ChangedFiles = []string{}
+	assert.True(t, config.OnlyChangedFiles())
+	assert.False(t, config.IsChanged("src/Foo.php"))
+}
@@ -0,0 +1,352 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onlishop/onlishop-cli/internal/system"
+	"github.com/onlishop/onlishop-cli/internal/twigparser"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/logging"
+)
+
+const storefrontTemplateNamespace = "@Storefront/"
+
+// TwigBlocks checks that the storefront blocks overridden by the extension still exist in the core templates
+type TwigBlocks struct{}
+
+func (t TwigBlocks) Name() string {
+	return "twig-blocks"
+}
+
+func (t TwigBlocks) Check(ctx context.Context, check *Check, config ToolConfig) error {
+	templates, err := findStorefrontOverrides(config)
+	if err != nil {
+		return err
+	}
+
+	if len(templates) == 0 {
+		return nil
+	}
+
+	// Apps don't have a composer.json and cannot install the storefront
+	if _, err := os.Stat(filepath.Join(config.RootDir, "composer.json")); err == nil {
+		if err := installComposerDeps(ctx, config.RootDir, config.CheckAgainst); err != nil {
+			return err
+		}
+	}
+
+	targetViews := filepath.Join(config.RootDir, "vendor", "onlishop", "storefront", "Resources", "views")
+	if _, err := os.Stat(targetViews); err != nil {
+		logging.FromContext(ctx).Debugf("Skipping twig block check, the storefront is not installed in %s", targetViews)
+		return nil
+	}
+
+	// The extension was written against the lowest supported version, compare its blocks with the checked version
+	referenceViews := ""
+	if config.CheckAgainst != "lowest" && config.MinOnlishopVersion != config.MaxOnlishopVersion {
+		referenceDir, err := cloneStorefrontViews(ctx, config.MinOnlishopVersion)
+		if err != nil {
+			logging.FromContext(ctx).Warnf("Cannot fetch the storefront %s, checking only for removed blocks: %v", config.MinOnlishopVersion, err)
+		} else {
+			referenceViews = filepath.Join(referenceDir, "Resources", "views")
+		}
+	}
+
+	for file, extends := range templates {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		relPath := strings.TrimPrefix(strings.TrimPrefix(file, "/private"), config.RootDir+"/")
+
+		target, err := parseCoreTemplate(targetViews, extends)
+		if err != nil {
+			return err
+		}
+
+		var reference twigparser.NodeList
+		if referenceViews != "" {
+			if reference, err = parseCoreTemplate(referenceViews, extends); err != nil {
+				return err
+			}
+		}
+
+		for _, result := range checkBlockCompatibility(string(content), extends, reference, target) {
+			result.Path = relPath
+			check.AddResult(result)
+		}
+	}
+
+	return nil
+}
+
+func (t TwigBlocks) Fix(ctx context.Context, config ToolConfig) error {
+	return nil
+}
+
+func (t TwigBlocks) Format(ctx context.Context, config ToolConfig, dryRun bool) error {
+	return nil
+}
+
+func init() {
+	AddTool(TwigBlocks{})
+}
+
+// findStorefrontOverrides returns the templates of the extension extending a storefront template with the extended template
+func findStorefrontOverrides(config ToolConfig) (map[string]string, error) {
+	templates := map[string]string{}
+
+	for _, sourceDirectory := range config.SourceDirectories {
+		viewsDir := filepath.Join(sourceDirectory, "Resources", "views")
+
+		if _, err := os.Stat(viewsDir); err != nil {
+			continue
+		}
+
+		err := filepath.WalkDir(viewsDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() || !strings.HasSuffix(path, ".html.twig") || !config.IsChanged(path) {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			nodes, err := twigparser.ParseTemplate(string(content))
+			if err != nil {
+				//nolint: nilerr
+				return nil
+			}
+
+			if extends := nodes.Extends(); extends != nil && strings.HasPrefix(extends.Template, storefrontTemplateNamespace) {
+				templates[path] = extends.Template
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+// parseCoreTemplate parses the core template including the templates it extends, so inherited blocks are found too.
+// A missing template returns nil.
+func parseCoreTemplate(viewsDir, template string) (twigparser.NodeList, error) {
+	var nodes twigparser.NodeList
+
+	seen := map[string]bool{}
+
+	for strings.HasPrefix(template, storefrontTemplateNamespace) && !seen[template] {
+		seen[template] = true
+
+		file := filepath.Join(viewsDir, filepath.FromSlash(strings.TrimPrefix(template, storefrontTemplateNamespace)))
+
+		content, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := twigparser.ParseTemplate(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse core template %s: %w", file, err)
+		}
+
+		// The blocks of the template itself come first, so they win over the blocks of its parents
+		nodes = append(nodes, parsed...)
+
+		extends := parsed.Extends()
+		if extends == nil {
+			break
+		}
+
+		template = extends.Template
+	}
+
+	return nodes, nil
+}
+
+// checkBlockCompatibility compares the blocks overridden by the extension template with the core template of the
+// checked version (target) and the version the extension was written for (reference, optional)
+func checkBlockCompatibility(content, extends string, reference, target twigparser.NodeList) []validation.CheckResult {
+	nodes, err := twigparser.ParseTemplate(content)
+	if err != nil {
+		return nil
+	}
+
+	if target == nil {
+		return []validation.CheckResult{{
+			Identifier: "twig-blocks/template-removed",
+			Message:    fmt.Sprintf("The extended template %s does not exist anymore", extends),
+			Severity:   validation.SeverityError,
+			Line:       1,
+		}}
+	}
+
+	var results []validation.CheckResult
+
+	for _, name := range nodes.BlockNames() {
+		// Blocks nested in an overridden block are new blocks of the extension
+		if !isOverriddenBlock(nodes, name) {
+			continue
+		}
+
+		targetBlock := target.FindBlock(name)
+
+		var referenceBlock *twigparser.BlockNode
+		if reference != nil {
+			referenceBlock = reference.FindBlock(name)
+		}
+
+		if targetBlock == nil {
+			message := fmt.Sprintf("The block %s does not exist in %s", name, extends)
+
+			if renamed := findRenamedBlock(name, referenceBlock, reference, target); renamed != "" {
+				message = fmt.Sprintf("The block %s does not exist in %s, it was probably renamed to %s", name, extends, renamed)
+			}
+
+			results = append(results, validation.CheckResult{
+				Identifier: "twig-blocks/block-removed",
+				Message:    message,
+				Severity:   validation.SeverityError,
+				Line:       blockLine(content, name),
+			})
+
+			continue
+		}
+
+		if referenceBlock != nil && normalizeMarkup(referenceBlock.Children.Dump()) != normalizeMarkup(targetBlock.Children.Dump()) {
+			results = append(results, validation.CheckResult{
+				Identifier: "twig-blocks/parent-changed",
+				Message:    fmt.Sprintf("The markup of the block %s changed in %s, check that the override is still correct", name, extends),
+				Severity:   validation.SeverityWarning,
+				Line:       blockLine(content, name),
+			})
+		}
+	}
+
+	return results
+}
+
+// isOverriddenBlock reports whether the block is a top level block of the template, nested blocks can be new blocks of the extension
+func isOverriddenBlock(nodes twigparser.NodeList, name string) bool {
+	for _, node := range nodes {
+		if block, ok := node.(*twigparser.BlockNode); ok && block.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findRenamedBlock searches the target for a block with the same markup or, without reference, the most similar name
+func findRenamedBlock(name string, referenceBlock *twigparser.BlockNode, reference, target twigparser.NodeList) string {
+	if referenceBlock != nil {
+		markup := normalizeMarkup(referenceBlock.Children.Dump())
+
+		for _, candidate := range target.BlockNames() {
+			if reference.FindBlock(candidate) != nil {
+				continue
+			}
+
+			if normalizeMarkup(target.FindBlock(candidate).Children.Dump()) == markup {
+				return candidate
+			}
+		}
+	}
+
+	// Renamed blocks usually keep the end of their name, e.g. page_product_detail_buy_form to buy_widget_buy_form
+	parts := strings.Split(name, "_")
+	best, bestScore := "", 1
+
+	for _, candidate := range target.BlockNames() {
+		if reference != nil && reference.FindBlock(candidate) != nil {
+			continue
+		}
+
+		candidateParts := strings.Split(candidate, "_")
+		score := 0
+
+		for score < len(parts) && score < len(candidateParts) && parts[len(parts)-1-score] == candidateParts[len(candidateParts)-1-score] {
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+
+	return best
+}
+
+func normalizeMarkup(markup string) string {
+	return strings.Join(strings.Fields(markup), " ")
+}
+
+func blockLine(content, name string) int {
+	loc := regexp.MustCompile(`\{%-?\s*block\s+` + regexp.QuoteMeta(name) + `\s`).FindStringIndex(content)
+	if loc == nil {
+		return 0
+	}
+
+	return strings.Count(content[:loc[0]], "\n") + 1
+}
+
+// cloneStorefrontViews returns the storefront of the version. The tags don't change, so the clone is cached across runs.
+func cloneStorefrontViews(ctx context.Context, version string) (string, error) {
+	cacheDir := filepath.Join(system.GetOnlishopCliCacheDir(), "storefront", version)
+
+	if _, err := os.Stat(filepath.Join(cacheDir, "Resources", "views")); err == nil {
+		logging.FromContext(ctx).Debugf("Using cached storefront %s", cacheDir)
+		return cacheDir, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cacheDir), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	// Clone next to the cache directory and move it, so an aborted clone is not used as cache
+	tempDir, err := os.MkdirTemp(filepath.Dir(cacheDir), version+"-")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	git := exec.CommandContext(ctx, "git", "-c", "advice.detachedHead=false", "clone", "-q", "--branch", "v"+version, "https://github.com/onlishop/storefront", tempDir, "--depth", "1")
+	if output, err := git.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("cannot clone the storefront: %w, %s", err, output)
+	}
+
+	if err := os.Rename(tempDir, cacheDir); err != nil {
+		// Another run cached the version in the meantime
+		if _, statErr := os.Stat(filepath.Join(cacheDir, "Resources", "views")); statErr == nil {
+			return cacheDir, nil
+		}
+
+		return "", err
+	}
+
+	return cacheDir, nil
+}
@@ -0,0 +1,108 @@
+package verifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onlishop/onlishop-cli/internal/twigparser"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+)
+
+const twigBlocksExtension = `{% sw_extends '@Storefront/storefront/page/product-detail/index.html.twig' %}
+
+{% block page_product_detail_buy_form %}
+    {% block my_extension_block %}<div>Extension</div>{% endblock %}
+    {{ parent() }}
+{% endblock %}
+`
+
+func mustParseTwig(t *testing.T, content string) twigparser.NodeList {
+	t.Helper()
+
+	nodes, err := twigparser.ParseTemplate(content)
+	require.NoError(t, err)
+
+	return nodes
+}
+
+func TestCheckBlockCompatibilityTemplateRemoved(t *testing.T) {
+	results := checkBlockCompatibility(twigBlocksExtension, "@Storefront/storefront/page/product-detail/index.html.twig", nil, nil)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "twig-blocks/template-removed", results[0].Identifier)
+	assert.Equal(t, validation.SeverityError, results[0].Severity)
+}
+
+func TestCheckBlockCompatibilityUnchanged(t *testing.T) {
+	core := mustParseTwig(t, `{% block page_product_detail_buy_form %}<form></form>{% endblock %}`)
+
+	results := checkBlockCompatibility(twigBlocksExtension, "@Storefront/index.html.twig", core, core)
+
+	assert.Empty(t, results)
+}
+
+func TestCheckBlockCompatibilityBlockRenamed(t *testing.T) {
+	reference := mustParseTwig(t, `{% block page_product_detail_buy_form %}<form></form>{% endblock %}`)
+	target := mustParseTwig(t, `{% block page_product_detail_buy %}{% block buy_widget_form %}<form></form>{% endblock %}{% endblock %}`)
+
+	results := checkBlockCompatibility(twigBlocksExtension, "@Storefront/index.html.twig", reference, target)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "twig-blocks/block-removed", results[0].Identifier)
+	assert.Equal(t, 3, results[0].Line)
+	assert.Contains(t, results[0].Message, "renamed to buy_widget_form")
+}
+
+func TestCheckBlockCompatibilityBlockRemovedWithoutReference(t *testing.T) {
+	target := mustParseTwig(t, `{% block page_product_detail_buy_widget_buy_form %}<form></form>{% endblock %}`)
+
+	results := checkBlockCompatibility(twigBlocksExtension, "@Storefront/index.html.twig", nil, target)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "twig-blocks/block-removed", results[0].Identifier)
+	assert.Contains(t, results[0].Message, "renamed to page_product_detail_buy_widget_buy_form")
+}
+
+func TestCheckBlockCompatibilityParentChanged(t *testing.T) {
+	reference := mustParseTwig(t, `{% block page_product_detail_buy_form %}<form></form>{% endblock %}`)
+	target := mustParseTwig(t, `{% block page_product_detail_buy_form %}<form class="buy-widget"></form>{% endblock %}`)
+
+	results := checkBlockCompatibility(twigBlocksExtension, "@Storefront/index.html.twig", reference, target)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "twig-blocks/parent-changed", results[0].Identifier)
+	assert.Equal(t, validation.SeverityWarning, results[0].Severity)
+}
+
+func TestParseCoreTemplateFollowsExtends(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "storefront", "page"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "storefront", "base.html.twig"), []byte(`{% block base_main %}{% endblock %}`), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "storefront", "page", "index.html.twig"), []byte(`{% sw_extends '@Storefront/storefront/base.html.twig' %}{% block page_main %}{% endblock %}`), os.ModePerm))
+
+	nodes, err := parseCoreTemplate(dir, "@Storefront/storefront/page/index.html.twig")
+	assert.NoError(t, err)
+	assert.NotNil(t, nodes.FindBlock("page_main"))
+	assert.NotNil(t, nodes.FindBlock("base_main"))
+
+	missing, err := parseCoreTemplate(dir, "@Storefront/storefront/missing.html.twig")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestCloneStorefrontViewsUsesCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("ONLISHOP_CLI_CACHE_DIR", cacheDir)
+
+	cached := filepath.Join(cacheDir, "storefront", "6.6.0.0")
+	assert.NoError(t, os.MkdirAll(filepath.Join(cached, "Resources", "views"), os.ModePerm))
+
+	dir, err := cloneStorefrontViews(t.Context(), "6.6.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, cached, dir)
+}
@@ -11,7 +11,7 @@ import (
 type AlertFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(AlertFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-alert", AlertFixer{})
 }
 
 func (a AlertFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -14,7 +14,7 @@ import (
 type ButtonFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(ButtonFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-button", ButtonFixer{})
 }
 
 func (b ButtonFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -11,7 +11,7 @@ import (
 type CardFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(CardFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-card", CardFixer{})
 }
 
 func (c CardFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type CheckboxFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(CheckboxFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-checkbox-field", CheckboxFieldFixer{})
 }
 
 func (c CheckboxFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
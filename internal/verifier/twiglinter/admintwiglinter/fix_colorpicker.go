@@ -13,7 +13,7 @@ import (
 type ColorpickerFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(ColorpickerFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-colorpicker", ColorpickerFixer{})
 }
 
 func (c ColorpickerFixer) Check(nodes []html.Node) []validation.CheckResult {
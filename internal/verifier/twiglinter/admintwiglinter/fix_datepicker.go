@@ -13,7 +13,7 @@ import (
 type DatepickerFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(DatepickerFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-datepicker", DatepickerFixer{})
 }
 
 func (d DatepickerFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type EmailFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(EmailFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-email-field", EmailFieldFixer{})
 }
 
 func (e EmailFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
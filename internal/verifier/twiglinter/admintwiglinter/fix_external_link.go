@@ -11,7 +11,7 @@ import (
 type ExternalLinkFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(ExternalLinkFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-external-link", ExternalLinkFixer{})
 }
 
 func (e ExternalLinkFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type IconFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(IconFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-icon", IconFixer{})
 }
 
 func (i IconFixer) Check(nodes []html.Node) []validation.CheckResult {
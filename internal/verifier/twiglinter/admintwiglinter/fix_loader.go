@@ -11,7 +11,7 @@ import (
 type LoaderFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(LoaderFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-loader", LoaderFixer{})
 }
 
 func (l LoaderFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type NumberFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(NumberFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-number-field", NumberFieldFixer{})
 }
 
 func (n NumberFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
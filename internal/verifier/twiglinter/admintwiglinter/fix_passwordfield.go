@@ -13,7 +13,7 @@ import (
 type PasswordFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(PasswordFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-password-field", PasswordFieldFixer{})
 }
 
 func (p PasswordFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
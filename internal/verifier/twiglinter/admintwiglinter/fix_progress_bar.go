@@ -11,7 +11,7 @@ import (
 type ProgressBarFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(ProgressBarFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-progress-bar", ProgressBarFixer{})
 }
 
 func (p ProgressBarFixer) Check(nodes []html.Node) []validation.CheckResult {
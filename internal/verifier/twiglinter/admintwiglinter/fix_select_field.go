@@ -14,7 +14,7 @@ import (
 type SelectFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(SelectFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-select-field", SelectFieldFixer{})
 }
 
 func (s SelectFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
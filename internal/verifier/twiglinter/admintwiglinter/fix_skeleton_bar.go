@@ -11,7 +11,7 @@ import (
 type SkeletonBarFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(SkeletonBarFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-skeleton-bar", SkeletonBarFixer{})
 }
 
 func (s SkeletonBarFixer) Check(nodes []html.Node) []validation.CheckResult {
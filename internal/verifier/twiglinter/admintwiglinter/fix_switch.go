@@ -13,7 +13,7 @@ import (
 type SwitchFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(SwitchFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-switch-field", SwitchFixer{})
 }
 
 func (s SwitchFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type TextFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(TextFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-text-field", TextFieldFixer{})
 }
 
 func (t TextFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type TextareaFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(TextareaFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-textarea-field", TextareaFieldFixer{})
 }
 
 func (t TextareaFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
@@ -13,7 +13,7 @@ import (
 type UrlFieldFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(UrlFieldFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-url-field", UrlFieldFixer{})
 }
 
 func (u UrlFieldFixer) Check(nodes []html.Node) []validation.CheckResult {
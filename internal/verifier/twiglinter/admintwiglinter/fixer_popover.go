@@ -11,7 +11,7 @@ import (
 type PopoverFixer struct{}
 
 func init() {
-	twiglinter.AddAdministrationFixer(PopoverFixer{})
+	twiglinter.AddAdministrationFixer("admintwiglinter/sw-popover", PopoverFixer{})
 }
 
 func (p PopoverFixer) Check(node []html.Node) []validation.CheckResult {
@@ -35,3 +35,45 @@ func RunCheckerOnString(fixer TwigFixer, content string) ([]validation.CheckResu
 
 	return fixer.Check(nodes), nil
 }
+
+// FixEdits returns the edits the fixer applies to the content. Edits are only computed when the parser reproduces
+// the unmodified content, otherwise they would contain unrelated formatting changes.
+func FixEdits(fixer TwigFixer, content string, parse func(string) (html.ConfiguredNodeList, error)) ([]validation.CheckEdit, error) {
+	parsed, err := parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dump drops the trailing newlines of the file
+	trimmed := strings.TrimRight(content, "\n")
+	if parsed.Dump(0) != trimmed {
+		return nil, nil
+	}
+
+	if err := fixer.Fix(parsed.Nodes); err != nil {
+		return nil, err
+	}
+
+	return validation.LineEdits(content, parsed.Dump(0)+content[len(trimmed):]), nil
+}
+
+// AttachEdits adds the edits to the results of a fixer. Each result gets the edits touching its line,
+// a single result without a line gets all edits.
+func AttachEdits(results []validation.CheckResult, edits []validation.CheckEdit) {
+	if len(edits) == 0 {
+		return
+	}
+
+	if len(results) == 1 && results[0].Line == 0 {
+		results[0].Edits = edits
+		return
+	}
+
+	for i := range results {
+		for _, edit := range edits {
+			if edit.ContainsLine(results[i].Line) {
+				results[i].Edits = append(results[i].Edits, edit)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package twiglinter
+
+import (
+	"testing"
+
+	"github.com/shyim/go-version"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+)
+
+type renameFixer struct{}
+
+func (r renameFixer) Check(nodes []html.Node) []validation.CheckResult {
+	var results []validation.CheckResult
+	html.TraverseNode(nodes, func(node *html.ElementNode) {
+		if node.Tag == "sw-old" {
+			results = append(results, validation.CheckResult{Identifier: "sw-old", Line: node.Line})
+		}
+	})
+	return results
+}
+
+func (r renameFixer) Supports(v *version.Version) bool {
+	return true
+}
+
+func (r renameFixer) Fix(nodes []html.Node) error {
+	html.TraverseNode(nodes, func(node *html.ElementNode) {
+		if node.Tag == "sw-old" {
+			node.Tag = "sw-new"
+		}
+	})
+	return nil
+}
+
+func TestFixEdits(t *testing.T) {
+	content := "<div>\n    <sw-old>a</sw-old>\n    <span>b</span>\n    <sw-old>c</sw-old>\n</div>\n"
+
+	parsed, err := html.NewAdminParser(content)
+	assert.NoError(t, err)
+
+	results := renameFixer{}.Check(parsed.Nodes)
+	assert.Len(t, results, 2)
+
+	edits, err := FixEdits(renameFixer{}, content, html.NewAdminParser)
+	assert.NoError(t, err)
+	assert.Len(t, edits, 2)
+	assert.Equal(t, "    <sw-new>a</sw-new>\n", edits[0].NewText)
+
+	AttachEdits(results, edits)
+	assert.Equal(t, []validation.CheckEdit{edits[0]}, results[0].Edits)
+	assert.Equal(t, []validation.CheckEdit{edits[1]}, results[1].Edits)
+}
+
+func TestFixEditsSkipsReformattedContent(t *testing.T) {
+	edits, err := FixEdits(renameFixer{}, "<div><sw-old>a</sw-old>   </div>", html.NewAdminParser)
+	assert.NoError(t, err)
+	assert.Empty(t, edits)
+}
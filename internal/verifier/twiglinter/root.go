@@ -11,35 +11,65 @@ var Onlishop67Constraint = version.MustConstraints(version.NewConstraint(">=6.7.
 
 const TwigExtension = ".twig"
 
-var availableStorefrontFixers = []TwigFixer{}
+var availableStorefrontRules = []Rule{}
 
-var availableAdministrationFixers = []TwigFixer{}
+var availableAdministrationRules = []Rule{}
 
-func AddStorefrontFixer(fixer TwigFixer) {
-	availableStorefrontFixers = append(availableStorefrontFixers, fixer)
+// Rule is a twig fixer registered with a stable ID, the ID is used to configure it in the validation rules
+type Rule struct {
+	ID    string
+	Fixer TwigFixer
 }
 
-func AddAdministrationFixer(fixer TwigFixer) {
-	availableAdministrationFixers = append(availableAdministrationFixers, fixer)
+func AddStorefrontFixer(id string, fixer TwigFixer) {
+	availableStorefrontRules = append(availableStorefrontRules, Rule{ID: id, Fixer: fixer})
+}
+
+func AddAdministrationFixer(id string, fixer TwigFixer) {
+	availableAdministrationRules = append(availableAdministrationRules, Rule{ID: id, Fixer: fixer})
+}
+
+func GetStorefrontRules(version *version.Version) []Rule {
+	return supportedRules(availableStorefrontRules, version)
+}
+
+func GetAdministrationRules(version *version.Version) []Rule {
+	return supportedRules(availableAdministrationRules, version)
 }
 
 func GetStorefrontFixers(version *version.Version) []TwigFixer {
-	fixers := []TwigFixer{}
-	for _, fixer := range availableStorefrontFixers {
-		if fixer.Supports(version) {
-			fixers = append(fixers, fixer)
+	return ruleFixers(GetStorefrontRules(version))
+}
+
+func GetAdministrationFixers(version *version.Version) []TwigFixer {
+	return ruleFixers(GetAdministrationRules(version))
+}
+
+// RuleIDs returns the IDs of all registered rules
+func RuleIDs() []string {
+	ids := []string{}
+	for _, rule := range append(append([]Rule{}, availableStorefrontRules...), availableAdministrationRules...) {
+		ids = append(ids, rule.ID)
+	}
+
+	return ids
+}
+
+func supportedRules(rules []Rule, version *version.Version) []Rule {
+	supported := []Rule{}
+	for _, rule := range rules {
+		if rule.Fixer.Supports(version) {
+			supported = append(supported, rule)
 		}
 	}
 
-	return fixers
+	return supported
 }
 
-func GetAdministrationFixers(version *version.Version) []TwigFixer {
+func ruleFixers(rules []Rule) []TwigFixer {
 	fixers := []TwigFixer{}
-	for _, fixer := range availableAdministrationFixers {
-		if fixer.Supports(version) {
-			fixers = append(fixers, fixer)
-		}
+	for _, rule := range rules {
+		fixers = append(fixers, rule.Fixer)
 	}
 
 	return fixers
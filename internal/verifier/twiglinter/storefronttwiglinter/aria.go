@@ -0,0 +1,167 @@
+package storefronttwiglinter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+// ariaAttributeValues contains all WAI-ARIA 1.2 attributes, attributes with a fixed set of values list them
+var ariaAttributeValues = map[string][]string{
+	"aria-activedescendant":       nil,
+	"aria-atomic":                 {"true", "false"},
+	"aria-autocomplete":           {"inline", "list", "both", "none"},
+	"aria-braillelabel":           nil,
+	"aria-brailleroledescription": nil,
+	"aria-busy":                   {"true", "false"},
+	"aria-checked":                {"true", "false", "mixed", "undefined"},
+	"aria-colcount":               nil,
+	"aria-colindex":               nil,
+	"aria-colindextext":           nil,
+	"aria-colspan":                nil,
+	"aria-controls":               nil,
+	"aria-current":                {"page", "step", "location", "date", "time", "true", "false"},
+	"aria-describedby":            nil,
+	"aria-description":            nil,
+	"aria-details":                nil,
+	"aria-disabled":               {"true", "false"},
+	"aria-dropeffect":             nil,
+	"aria-errormessage":           nil,
+	"aria-expanded":               {"true", "false", "undefined"},
+	"aria-flowto":                 nil,
+	"aria-grabbed":                {"true", "false", "undefined"},
+	"aria-haspopup":               {"true", "false", "menu", "listbox", "tree", "grid", "dialog"},
+	"aria-hidden":                 {"true", "false", "undefined"},
+	"aria-invalid":                {"true", "false", "grammar", "spelling"},
+	"aria-keyshortcuts":           nil,
+	"aria-label":                  nil,
+	"aria-labelledby":             nil,
+	"aria-level":                  nil,
+	"aria-live":                   {"assertive", "off", "polite"},
+	"aria-modal":                  {"true", "false"},
+	"aria-multiline":              {"true", "false"},
+	"aria-multiselectable":        {"true", "false"},
+	"aria-orientation":            {"horizontal", "vertical", "undefined"},
+	"aria-owns":                   nil,
+	"aria-placeholder":            nil,
+	"aria-posinset":               nil,
+	"aria-pressed":                {"true", "false", "mixed", "undefined"},
+	"aria-readonly":               {"true", "false"},
+	"aria-relevant":               nil,
+	"aria-required":               {"true", "false"},
+	"aria-roledescription":        nil,
+	"aria-rowcount":               nil,
+	"aria-rowindex":               nil,
+	"aria-rowindextext":           nil,
+	"aria-rowspan":                nil,
+	"aria-selected":               {"true", "false", "undefined"},
+	"aria-setsize":                nil,
+	"aria-sort":                   {"ascending", "descending", "none", "other"},
+	"aria-valuemax":               nil,
+	"aria-valuemin":               nil,
+	"aria-valuenow":               nil,
+	"aria-valuetext":              nil,
+}
+
+// ariaRoles contains all non abstract WAI-ARIA 1.2 roles
+var ariaRoles = []string{
+	"alert", "alertdialog", "application", "article", "banner", "blockquote", "button", "caption", "cell", "checkbox",
+	"code", "columnheader", "combobox", "complementary", "contentinfo", "definition", "deletion", "dialog", "directory",
+	"document", "emphasis", "feed", "figure", "form", "generic", "grid", "gridcell", "group", "heading", "img", "insertion",
+	"link", "list", "listbox", "listitem", "log", "main", "marquee", "math", "menu", "menubar", "menuitem",
+	"menuitemcheckbox", "menuitemradio", "meter", "navigation", "none", "note", "option", "paragraph", "presentation",
+	"progressbar", "radio", "radiogroup", "region", "row", "rowgroup", "rowheader", "scrollbar", "search", "searchbox",
+	"separator", "slider", "spinbutton", "status", "strong", "subscript", "superscript", "switch", "tab", "table",
+	"tablist", "tabpanel", "term", "textbox", "time", "timer", "toolbar", "tooltip", "tree", "treegrid", "treeitem",
+}
+
+type AriaCheck struct{}
+
+func (a AriaCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+	traverseElements(nodes, func(node *html.ElementNode) {
+		for _, attr := range node.Attributes {
+			attrElement, ok := attr.(html.Attribute)
+			if !ok {
+				continue
+			}
+
+			if attrElement.Key == "role" {
+				errors = append(errors, a.checkRole(node, attrElement.Value)...)
+				continue
+			}
+
+			if !strings.HasPrefix(attrElement.Key, "aria-") {
+				continue
+			}
+
+			allowedValues, known := ariaAttributeValues[attrElement.Key]
+			if !known {
+				errors = append(errors, validation.CheckResult{
+					Message:    fmt.Sprintf("Unknown ARIA attribute %q", attrElement.Key),
+					Severity:   validation.SeverityWarning,
+					Identifier: "twig-linter/aria-unknown-attribute",
+					Line:       node.Line,
+				})
+
+				continue
+			}
+
+			value := strings.TrimSpace(attrElement.Value)
+			if allowedValues == nil || containsTwig(value) || slices.Contains(allowedValues, value) {
+				continue
+			}
+
+			errors = append(errors, validation.CheckResult{
+				Message:    fmt.Sprintf("Invalid value %q for %s, allowed values are: %s", value, attrElement.Key, strings.Join(allowedValues, ", ")),
+				Severity:   validation.SeverityWarning,
+				Identifier: "twig-linter/aria-invalid-value",
+				Line:       node.Line,
+			})
+		}
+	})
+
+	return errors
+}
+
+func (a AriaCheck) checkRole(node *html.ElementNode, value string) []validation.CheckResult {
+	if containsTwig(value) {
+		return nil
+	}
+
+	var errors []validation.CheckResult
+
+	// The role attribute can contain fallback roles separated by spaces
+	for _, role := range strings.Fields(value) {
+		if slices.Contains(ariaRoles, role) {
+			continue
+		}
+
+		errors = append(errors, validation.CheckResult{
+			Message:    fmt.Sprintf("Unknown ARIA role %q", role),
+			Severity:   validation.SeverityWarning,
+			Identifier: "twig-linter/aria-unknown-role",
+			Line:       node.Line,
+		})
+	}
+
+	return errors
+}
+
+func (a AriaCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (a AriaCheck) Fix(nodes []html.Node) error {
+	return nil
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/aria", AriaCheck{})
+}
@@ -0,0 +1,34 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestAriaDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Valid attributes", content: `<button aria-expanded="false" aria-controls="menu">Menu</button>`, expectedCount: 0},
+		{name: "Valid role", content: `<div role="dialog" aria-modal="true"></div>`, expectedCount: 0},
+		{name: "Dynamic value", content: `<div aria-hidden="{{ hidden }}"></div>`, expectedCount: 0},
+		{name: "Unknown role in condition", content: `{% if a %}<div role="dialogue"></div>{% endif %}`, expectedCount: 1},
+		{name: "Unknown attribute", content: `<div aria-labeledby="title"></div>`, expectedCount: 1},
+		{name: "Invalid value", content: `<div aria-hidden="yes"></div>`, expectedCount: 1},
+		{name: "Unknown role", content: `<div role="dialogue"></div>`, expectedCount: 1},
+		{name: "Fallback roles", content: `<div role="switch checkbox"></div>`, expectedCount: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(AriaCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
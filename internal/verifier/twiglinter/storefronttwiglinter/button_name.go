@@ -0,0 +1,45 @@
+package storefronttwiglinter
+
+import (
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+type ButtonNameCheck struct{}
+
+func (b ButtonNameCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+	traverseElements(nodes, func(node *html.ElementNode) {
+		if node.Tag != "button" {
+			return
+		}
+
+		if hasAccessibleName(node) || hasTextContent(node.Children) {
+			return
+		}
+
+		errors = append(errors, validation.CheckResult{
+			Message:    "Buttons must have an accessible name, add a text or an aria-label",
+			Severity:   validation.SeverityWarning,
+			Identifier: "twig-linter/button-missing-name",
+			Line:       node.Line,
+		})
+	})
+
+	return errors
+}
+
+func (b ButtonNameCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (b ButtonNameCheck) Fix(nodes []html.Node) error {
+	return nil // The name has to describe the action, requires manual intervention
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/button-name", ButtonNameCheck{})
+}
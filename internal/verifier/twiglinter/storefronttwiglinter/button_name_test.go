@@ -0,0 +1,36 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestButtonNameDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Button with text", content: `<button type="submit">Send</button>`, expectedCount: 0},
+		{name: "Button with translated text", content: `<button>{{ "general.send"|trans }}</button>`, expectedCount: 0},
+		{name: "Button with aria-label", content: `<button aria-label="Close"><span class="icon"></span></button>`, expectedCount: 0},
+		{name: "Button with nested text", content: `<button><span>Close</span></button>`, expectedCount: 0},
+		{name: "Button with image alt", content: `<button><img src="close.svg" alt="Close"></button>`, expectedCount: 0},
+		{name: "Empty button in condition", content: `{% if a %}<button></button>{% endif %}`, expectedCount: 1},
+		{name: "Empty button in else", content: `{% if a %}<button>Send</button>{% else %}<button></button>{% endif %}`, expectedCount: 1},
+		{name: "Empty button", content: `<button></button>`, expectedCount: 1},
+		{name: "Icon only button", content: `<button class="btn"><span class="icon"></span></button>`, expectedCount: 1},
+		{name: "Button with twig tag only", content: `<button>{% sw_icon "x" %}</button>`, expectedCount: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(ButtonNameCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
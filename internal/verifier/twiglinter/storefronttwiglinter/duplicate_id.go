@@ -0,0 +1,77 @@
+package storefronttwiglinter
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+type DuplicateIDCheck struct{}
+
+func (d DuplicateIDCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+	d.check(nodes, map[string]int{}, &errors)
+
+	return errors
+}
+
+// check reports the ids already contained in firstLines. The branches of a twig condition are alternatives,
+// each branch starts with the ids in front of the condition and the ids of all branches are known after it.
+func (d DuplicateIDCheck) check(nodes html.NodeList, firstLines map[string]int, errors *[]validation.CheckResult) {
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case *html.ElementNode:
+			if id, ok := getAttribute(node, "id"); ok && id != "" && !containsTwig(id) {
+				if firstLine, exists := firstLines[id]; exists {
+					*errors = append(*errors, validation.CheckResult{
+						Message:    fmt.Sprintf("The id %q is already used on line %d, ids must be unique", id, firstLine),
+						Severity:   validation.SeverityWarning,
+						Identifier: "twig-linter/duplicate-id",
+						Line:       node.Line,
+					})
+				} else {
+					firstLines[id] = node.Line
+				}
+			}
+
+			d.check(node.Children, firstLines, errors)
+		case *html.TwigBlockNode:
+			d.check(node.Children, firstLines, errors)
+		case *html.TwigIfNode:
+			branches := append([]html.NodeList{node.Children}, node.ElseIfChildren...)
+			branches = append(branches, node.ElseChildren)
+
+			seen := map[string]int{}
+
+			for _, branch := range branches {
+				branchLines := maps.Clone(firstLines)
+				d.check(branch, branchLines, errors)
+
+				for id, line := range branchLines {
+					if _, exists := seen[id]; !exists {
+						seen[id] = line
+					}
+				}
+			}
+
+			maps.Copy(firstLines, seen)
+		}
+	}
+}
+
+func (d DuplicateIDCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (d DuplicateIDCheck) Fix(nodes []html.Node) error {
+	return nil // Ids are referenced by labels, scripts and styles, requires manual intervention
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/duplicate-id", DuplicateIDCheck{})
+}
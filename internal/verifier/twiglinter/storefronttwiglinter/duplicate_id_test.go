@@ -0,0 +1,34 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestDuplicateIDDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Unique ids", content: `<div id="a"></div><div id="b"></div>`, expectedCount: 0},
+		{name: "Dynamic ids", content: `<div id="item-{{ id }}"></div><div id="item-{{ id }}"></div>`, expectedCount: 0},
+		{name: "Ids in if and else", content: `{% if a %}<div id="a"></div>{% elseif b %}<div id="a"></div>{% else %}<span id="a"></span>{% endif %}`, expectedCount: 0},
+		{name: "Duplicate id in condition", content: `<div id="a"></div>{% if a %}<span id="a"></span>{% endif %}`, expectedCount: 1},
+		{name: "Duplicate id after condition", content: `{% if a %}<span id="a"></span>{% else %}<span id="a"></span>{% endif %}<div id="a"></div>`, expectedCount: 1},
+		{name: "Duplicate id inside branch", content: `{% if a %}<span id="a"></span><div id="a"></div>{% endif %}`, expectedCount: 1},
+		{name: "Duplicate id", content: `<div id="a"></div><span id="a"></span>`, expectedCount: 1},
+		{name: "Triple id", content: `<div id="a"></div><span id="a"></span><p id="a"></p>`, expectedCount: 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(DuplicateIDCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package storefronttwiglinter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+// Input types which are labelled by their value or are not visible
+var unlabeledInputTypes = []string{"hidden", "submit", "button", "reset", "image"}
+
+type FormLabelCheck struct{}
+
+func (f FormLabelCheck) Check(nodes []html.Node) []validation.CheckResult {
+	labelFor := map[string]bool{}
+	wrapped := map[*html.ElementNode]bool{}
+
+	traverseElements(nodes, func(node *html.ElementNode) {
+		if node.Tag != "label" {
+			return
+		}
+
+		if forValue, ok := getAttribute(node, "for"); ok {
+			labelFor[forValue] = true
+		}
+
+		traverseElements(node.Children, func(child *html.ElementNode) {
+			wrapped[child] = true
+		})
+	})
+
+	var errors []validation.CheckResult
+	traverseElements(nodes, func(node *html.ElementNode) {
+		if node.Tag != "input" && node.Tag != "select" && node.Tag != "textarea" {
+			return
+		}
+
+		if node.Tag == "input" {
+			inputType, _ := getAttribute(node, "type")
+			for _, t := range unlabeledInputTypes {
+				if strings.EqualFold(inputType, t) {
+					return
+				}
+			}
+		}
+
+		if wrapped[node] || hasAccessibleName(node) {
+			return
+		}
+
+		if id, ok := getAttribute(node, "id"); ok && (labelFor[id] || containsTwig(id)) {
+			return
+		}
+
+		errors = append(errors, validation.CheckResult{
+			Message:    fmt.Sprintf("Form field <%s> must have a label, use <label for=\"...\">, wrap it in a <label> or add an aria-label", node.Tag),
+			Severity:   validation.SeverityWarning,
+			Identifier: "twig-linter/form-field-missing-label",
+			Line:       node.Line,
+		})
+	})
+
+	return errors
+}
+
+func (f FormLabelCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (f FormLabelCheck) Fix(nodes []html.Node) error {
+	return nil // Labels need a meaningful text, requires manual intervention
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/form-label", FormLabelCheck{})
+}
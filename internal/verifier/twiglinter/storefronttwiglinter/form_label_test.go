@@ -0,0 +1,37 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestFormLabelDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Input with label for", content: `<label for="email">Email</label><input id="email" type="email">`, expectedCount: 0},
+		{name: "Input wrapped in label", content: `<label>Email <input type="email"></label>`, expectedCount: 0},
+		{name: "Input with aria-label", content: `<input type="search" aria-label="Search">`, expectedCount: 0},
+		{name: "Input with dynamic id", content: `<input id="{{ id }}" type="text">`, expectedCount: 0},
+		{name: "Hidden input", content: `<input type="hidden" name="token">`, expectedCount: 0},
+		{name: "Submit input", content: `<input type="submit" value="Send">`, expectedCount: 0},
+		{name: "Input without label in condition", content: `{% if a %}<input type="text" name="x">{% endif %}`, expectedCount: 1},
+		{name: "Input with label in condition", content: `{% if a %}<label for="x">X</label><input id="x" type="text">{% endif %}`, expectedCount: 0},
+		{name: "Input without label", content: `<input type="text" name="name">`, expectedCount: 1},
+		{name: "Label for another field", content: `<label for="other">Other</label><input id="email" type="email">`, expectedCount: 1},
+		{name: "Select and textarea without label", content: `<select name="a"><option>1</option></select><textarea name="b"></textarea>`, expectedCount: 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(FormLabelCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
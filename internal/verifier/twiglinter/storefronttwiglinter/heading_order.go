@@ -0,0 +1,54 @@
+package storefronttwiglinter
+
+import (
+	"fmt"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+var headingLevels = map[string]int{"h1": 1, "h2": 2, "h3": 3, "h4": 4, "h5": 5, "h6": 6}
+
+type HeadingOrderCheck struct{}
+
+func (h HeadingOrderCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+
+	// Templates are mostly partials, so the first heading can start at any level
+	previous := 0
+
+	traverseElements(nodes, func(node *html.ElementNode) {
+		level, ok := headingLevels[node.Tag]
+		if !ok {
+			return
+		}
+
+		if previous > 0 && level > previous+1 {
+			errors = append(errors, validation.CheckResult{
+				Message:    fmt.Sprintf("Heading levels should only increase by one, found <%s> after <h%d>", node.Tag, previous),
+				Severity:   validation.SeverityWarning,
+				Identifier: "twig-linter/heading-level-skip",
+				Line:       node.Line,
+			})
+		}
+
+		previous = level
+	})
+
+	return errors
+}
+
+func (h HeadingOrderCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (h HeadingOrderCheck) Fix(nodes []html.Node) error {
+	return nil // Changing the level changes the styling, requires manual intervention
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/heading-order", HeadingOrderCheck{})
+}
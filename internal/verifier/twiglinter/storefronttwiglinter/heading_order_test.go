@@ -0,0 +1,31 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestHeadingOrderDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Sequential headings", content: `<h1>A</h1><h2>B</h2><h3>C</h3><h2>D</h2>`, expectedCount: 0},
+		{name: "Partial starting at h3", content: `<h3>A</h3><h4>B</h4>`, expectedCount: 0},
+		{name: "Skipped level", content: `<h2>A</h2><h4>B</h4>`, expectedCount: 1},
+		{name: "Skipped level in condition", content: `<h2>A</h2>{% if a %}<h4>B</h4>{% endif %}`, expectedCount: 1},
+		{name: "Nested skipped level", content: `<h1>A</h1><div><section><h3>B</h3></section></div>`, expectedCount: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(HeadingOrderCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
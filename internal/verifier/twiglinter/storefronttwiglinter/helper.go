@@ -0,0 +1,163 @@
+package storefronttwiglinter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+)
+
+// getAttribute returns the value of the static attribute, attributes inside twig conditions are ignored
+func getAttribute(node *html.ElementNode, key string) (string, bool) {
+	for _, attr := range node.Attributes {
+		attrElement, ok := attr.(html.Attribute)
+		if ok && attrElement.Key == key {
+			return attrElement.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// hasDynamicAttributes reports whether attributes are added by twig, e.g. with {% if %} or {{ attributes }}
+func hasDynamicAttributes(node *html.ElementNode) bool {
+	for _, attr := range node.Attributes {
+		attrElement, ok := attr.(html.Attribute)
+		if !ok || containsTwig(attrElement.Key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsTwig reports whether the value is computed by twig and cannot be checked statically
+func containsTwig(value string) bool {
+	return strings.Contains(value, "{{") || strings.Contains(value, "{%")
+}
+
+// hasAccessibleName reports whether the element is named by an aria-label, aria-labelledby or title attribute
+func hasAccessibleName(node *html.ElementNode) bool {
+	for _, key := range []string{"aria-label", "aria-labelledby", "title"} {
+		if value, ok := getAttribute(node, key); ok && strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+
+	return hasDynamicAttributes(node)
+}
+
+// hasTextContent reports whether the nodes render text, twig output is assumed to render text
+func hasTextContent(nodes html.NodeList) bool {
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case *html.RawNode:
+			text := strings.TrimSpace(node.Text)
+			if text != "" && !strings.HasPrefix(text, "{%") {
+				return true
+			}
+		case *html.TemplateExpressionNode:
+			return true
+		case *html.ElementNode:
+			if node.Tag == "img" {
+				if alt, ok := getAttribute(node, "alt"); ok && strings.TrimSpace(alt) != "" {
+					return true
+				}
+
+				continue
+			}
+
+			if hasAccessibleName(node) || hasTextContent(node.Children) {
+				return true
+			}
+		case *html.TwigBlockNode:
+			if hasTextContent(node.Children) {
+				return true
+			}
+		case *html.TwigIfNode:
+			if hasTextContent(node.Children) || hasTextContent(node.ElseChildren) {
+				return true
+			}
+
+			for _, children := range node.ElseIfChildren {
+				if hasTextContent(children) {
+					return true
+				}
+			}
+		case *html.ParentNode:
+			// The parent block can render anything
+			return true
+		}
+	}
+
+	return false
+}
+
+// walkNodes calls fn for all nodes including the branches of twig conditions, attributes are not visited
+func walkNodes(nodes html.NodeList, fn func(node html.Node)) {
+	for _, node := range nodes {
+		fn(node)
+
+		switch node := node.(type) {
+		case *html.ElementNode:
+			walkNodes(node.Children, fn)
+		case *html.TwigBlockNode:
+			walkNodes(node.Children, fn)
+		case *html.TwigIfNode:
+			walkNodes(node.Children, fn)
+
+			for _, children := range node.ElseIfChildren {
+				walkNodes(children, fn)
+			}
+
+			walkNodes(node.ElseChildren, fn)
+		}
+	}
+}
+
+// traverseElements calls fn for all elements including the elements in the branches of twig conditions
+func traverseElements(nodes html.NodeList, fn func(node *html.ElementNode)) {
+	walkNodes(nodes, func(node html.Node) {
+		if element, ok := node.(*html.ElementNode); ok {
+			fn(element)
+		}
+	})
+}
+
+var conditionalAttributeRegExp = regexp.MustCompile(`([^\s=]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// elementAttributes returns the attributes of the element including the attributes added in twig conditions
+func elementAttributes(node *html.ElementNode) []html.Attribute {
+	var attributes []html.Attribute
+
+	var collect func(nodes html.NodeList)
+	collect = func(nodes html.NodeList) {
+		// Attributes in twig conditions are parsed as text
+		var text strings.Builder
+
+		for _, attr := range nodes {
+			switch attr := attr.(type) {
+			case html.Attribute:
+				attributes = append(attributes, attr)
+			case *html.RawNode, *html.TemplateExpressionNode:
+				text.WriteString(attr.Dump(0))
+			case *html.TwigIfNode:
+				collect(attr.Children)
+
+				for _, children := range attr.ElseIfChildren {
+					collect(children)
+				}
+
+				collect(attr.ElseChildren)
+			}
+		}
+
+		for _, match := range conditionalAttributeRegExp.FindAllStringSubmatch(text.String(), -1) {
+			attributes = append(attributes, html.Attribute{Key: match[1], Value: match[2] + match[3]})
+		}
+	}
+
+	collect(node.Attributes)
+
+	return attributes
+}
@@ -0,0 +1,47 @@
+package storefronttwiglinter
+
+import (
+	"strings"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+type HTMLLangCheck struct{}
+
+func (h HTMLLangCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+	traverseElements(nodes, func(node *html.ElementNode) {
+		if node.Tag != "html" {
+			return
+		}
+
+		if lang, ok := getAttribute(node, "lang"); (ok && strings.TrimSpace(lang) != "") || hasDynamicAttributes(node) {
+			return
+		}
+
+		errors = append(errors, validation.CheckResult{
+			Message:    "The html element must have a lang attribute",
+			Severity:   validation.SeverityWarning,
+			Identifier: "twig-linter/html-missing-lang",
+			Line:       node.Line,
+		})
+	})
+
+	return errors
+}
+
+func (h HTMLLangCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (h HTMLLangCheck) Fix(nodes []html.Node) error {
+	return nil // The language depends on the sales channel, requires manual intervention
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/html-lang", HTMLLangCheck{})
+}
@@ -0,0 +1,31 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestHTMLLangDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Html with lang", content: `<html lang="{{ page.header.activeLanguage.translationCode.code }}"><body></body></html>`, expectedCount: 0},
+		{name: "Html without lang", content: `<html><body></body></html>`, expectedCount: 1},
+		{name: "Html without lang in condition", content: `{% if a %}<html><body></body></html>{% endif %}`, expectedCount: 1},
+		{name: "Html with empty lang", content: `<html lang=""><body></body></html>`, expectedCount: 1},
+		{name: "Partial without html", content: `<div></div>`, expectedCount: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(HTMLLangCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
@@ -64,5 +64,5 @@ func (i ImageAltCheck) Fix(nodes []html.Node) error {
 }
 
 func init() {
-	twiglinter.AddStorefrontFixer(ImageAltCheck{})
+	twiglinter.AddStorefrontFixer("twig-linter/image-alt", ImageAltCheck{})
 }
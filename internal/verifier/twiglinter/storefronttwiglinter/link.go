@@ -86,5 +86,5 @@ func (l LinkCheck) Fix(nodes []html.Node) error {
 }
 
 func init() {
-	twiglinter.AddStorefrontFixer(LinkCheck{})
+	twiglinter.AddStorefrontFixer("twig-linter/external-link", LinkCheck{})
 }
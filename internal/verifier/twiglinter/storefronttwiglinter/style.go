@@ -48,5 +48,5 @@ func (s StyleFixer) Fix(nodes []html.Node) error {
 }
 
 func init() {
-	twiglinter.AddStorefrontFixer(StyleFixer{})
+	twiglinter.AddStorefrontFixer("twig-linter/inline-style", StyleFixer{})
 }
@@ -0,0 +1,50 @@
+package storefronttwiglinter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+type TabindexCheck struct{}
+
+func (t TabindexCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+	traverseElements(nodes, func(node *html.ElementNode) {
+		value, ok := getAttribute(node, "tabindex")
+		if !ok {
+			return
+		}
+
+		tabindex, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || tabindex <= 0 {
+			return
+		}
+
+		errors = append(errors, validation.CheckResult{
+			Message:    "Avoid a tabindex greater than 0, it breaks the natural tab order. Use 0 or -1 instead",
+			Severity:   validation.SeverityWarning,
+			Identifier: "twig-linter/positive-tabindex",
+			Line:       node.Line,
+		})
+	})
+
+	return errors
+}
+
+func (t TabindexCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (t TabindexCheck) Fix(nodes []html.Node) error {
+	return nil // The intended tab order is unknown, requires manual intervention
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/tabindex", TabindexCheck{})
+}
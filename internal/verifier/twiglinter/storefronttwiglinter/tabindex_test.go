@@ -0,0 +1,31 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestTabindexDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Tabindex zero", content: `<div tabindex="0"></div>`, expectedCount: 0},
+		{name: "Negative tabindex", content: `<div tabindex="-1"></div>`, expectedCount: 0},
+		{name: "Dynamic tabindex", content: `<div tabindex="{{ index }}"></div>`, expectedCount: 0},
+		{name: "Positive tabindex in condition", content: `{% if a %}<div tabindex="2"></div>{% endif %}`, expectedCount: 1},
+		{name: "Positive tabindex", content: `<a href="#" tabindex="3">Link</a>`, expectedCount: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(TabindexCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package storefronttwiglinter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shyim/go-version"
+
+	"github.com/onlishop/onlishop-cli/internal/html"
+	"github.com/onlishop/onlishop-cli/internal/twigparser"
+	"github.com/onlishop/onlishop-cli/internal/validation"
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+var (
+	twigTagRegExp        = regexp.MustCompile(`(?s)\{%(.*?)%\}`)
+	twigExpressionRegExp = regexp.MustCompile(`(?s)\{\{(.*?)\}\}`)
+	eventHandlerRegExp   = regexp.MustCompile(`^on[a-z]+$`)
+)
+
+// sanitizingFilters return markup which is safe to output with |raw. striptags only without allowed tags,
+// the attributes of allowed tags like <img onerror=...> are kept.
+var sanitizingFilters = map[string]bool{
+	"sw_sanitize": true,
+	"escape":      true,
+	"e":           true,
+	"striptags":   true,
+}
+
+// safeFunctions return markup of the templates or urls, but no user data
+var safeFunctions = map[string]bool{
+	"block":   true,
+	"parent":  true,
+	"include": true,
+	"source":  true,
+	"path":    true,
+	"url":     true,
+	"asset":   true,
+}
+
+type UnsafeOutputCheck struct{}
+
+func (u UnsafeOutputCheck) Check(nodes []html.Node) []validation.CheckResult {
+	var errors []validation.CheckResult
+
+	walkNodes(nodes, func(node html.Node) {
+		switch node := node.(type) {
+		case *html.TemplateExpressionNode:
+			errors = append(errors, checkRawOutput(node.Expression, node.Line)...)
+		case *html.RawNode:
+			for _, match := range twigTagRegExp.FindAllStringSubmatchIndex(node.Text, -1) {
+				tag, err := twigparser.ParseTag(node.Text[match[2]:match[3]])
+				if err != nil || tag.Name != "autoescape" || len(tag.Expressions) == 0 {
+					continue
+				}
+
+				if constant, ok := tag.Expressions[0].(*twigparser.ConstantExpression); ok && strings.EqualFold(constant.Value, "false") {
+					errors = append(errors, validation.CheckResult{
+						Message:    "{% autoescape false %} disables the escaping of all output in the block, escape the output or use |sw_sanitize instead",
+						Severity:   validation.SeverityWarning,
+						Identifier: "twig-linter/autoescape-disabled",
+						Line:       node.Line + strings.Count(node.Text[:match[0]], "\n"),
+					})
+				}
+			}
+		case *html.ElementNode:
+			for _, attr := range elementAttributes(node) {
+				for _, match := range twigExpressionRegExp.FindAllStringSubmatch(attr.Value, -1) {
+					errors = append(errors, checkRawOutput(match[1], node.Line)...)
+				}
+
+				if eventHandlerRegExp.MatchString(strings.ToLower(attr.Key)) && containsUnescapedJavaScript(attr.Value) {
+					errors = append(errors, validation.CheckResult{
+						Message:    fmt.Sprintf("The inline event handler %s of <%s> contains template output, the html escaping does not protect JavaScript. Pass the data with a data attribute to a JavaScript plugin instead", attr.Key, node.Tag),
+						Severity:   validation.SeverityError,
+						Identifier: "twig-linter/inline-event-handler",
+						Line:       node.Line,
+					})
+				}
+			}
+		}
+	})
+
+	return errors
+}
+
+func (u UnsafeOutputCheck) Supports(v *version.Version) bool {
+	return true
+}
+
+func (u UnsafeOutputCheck) Fix(nodes []html.Node) error {
+	return nil // Whether the output is safe requires manual review
+}
+
+func init() {
+	twiglinter.AddStorefrontFixer("twig-linter/unsafe-output", UnsafeOutputCheck{})
+}
+
+// checkRawOutput reports |raw applied to output which is not proven to be safe
+func checkRawOutput(source string, line int) []validation.CheckResult {
+	expr, err := twigparser.ParseExpression(strings.Trim(strings.TrimSpace(source), "-~"))
+	if err != nil {
+		return nil
+	}
+
+	var errors []validation.CheckResult
+
+	twigparser.InspectExpression(expr, func(expr twigparser.Expression) bool {
+		filter, ok := expr.(*twigparser.FilterExpression)
+		if !ok || filter.Name != "raw" || filter.Node == nil || isSafeOutput(filter.Node) {
+			return true
+		}
+
+		errors = append(errors, validation.CheckResult{
+			Message:    fmt.Sprintf("%s is printed with |raw without being sanitized, use |sw_sanitize to prevent XSS", filter.Node.Dump()),
+			Severity:   validation.SeverityError,
+			Identifier: "twig-linter/unsafe-raw",
+			Line:       line,
+		})
+
+		return false
+	})
+
+	return errors
+}
+
+// isSafeOutput reports whether the expression cannot contain user data or is sanitized
+func isSafeOutput(expr twigparser.Expression) bool {
+	switch e := expr.(type) {
+	case *twigparser.StringExpression, *twigparser.NumberExpression, *twigparser.ConstantExpression, *twigparser.TestExpression, *twigparser.UnaryExpression:
+		return true
+	case *twigparser.InterpolatedStringExpression:
+		for _, part := range e.Parts {
+			if !isSafeOutput(part) {
+				return false
+			}
+		}
+		return true
+	case *twigparser.BinaryExpression:
+		// Other operators return numbers, booleans or arrays
+		if e.Operator == "~" || e.Operator == "??" {
+			return isSafeOutput(e.Left) && isSafeOutput(e.Right)
+		}
+		return true
+	case *twigparser.ConditionalExpression:
+		then := e.Then
+		if then == nil {
+			then = e.Condition
+		}
+		return isSafeOutput(then) && (e.Else == nil || isSafeOutput(e.Else))
+	case *twigparser.FilterExpression:
+		if sanitizingFilters[e.Name] && (e.Name != "striptags" || len(e.Arguments) == 0) {
+			return true
+		}
+
+		// Other filters transform their input, the arguments like in '%s'|format(name) can end up in the output
+		for _, argument := range e.Arguments {
+			if !isSafeOutput(argument.Value) {
+				return false
+			}
+		}
+		return e.Node != nil && isSafeOutput(e.Node)
+	case *twigparser.FunctionExpression:
+		return safeFunctions[e.Name]
+	}
+
+	return false
+}
+
+// containsUnescapedJavaScript reports whether the attribute value contains twig which is not escaped with the js strategy
+func containsUnescapedJavaScript(value string) bool {
+	if !containsTwig(value) {
+		return false
+	}
+
+	if strings.Contains(value, "{%") {
+		return true
+	}
+
+	for _, match := range twigExpressionRegExp.FindAllStringSubmatch(value, -1) {
+		expr, err := twigparser.ParseExpression(strings.Trim(strings.TrimSpace(match[1]), "-~"))
+		if err != nil || !isJavaScriptEscaped(expr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isJavaScriptEscaped reports whether the output is escaped with |e('js') or |escape('js')
+func isJavaScriptEscaped(expr twigparser.Expression) bool {
+	filter, ok := expr.(*twigparser.FilterExpression)
+	if !ok || (filter.Name != "e" && filter.Name != "escape") || len(filter.Arguments) == 0 {
+		return false
+	}
+
+	strategy, ok := filter.Arguments[0].Value.(*twigparser.StringExpression)
+
+	return ok && strategy.Value == "js"
+}
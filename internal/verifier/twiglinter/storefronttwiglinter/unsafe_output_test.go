@@ -0,0 +1,54 @@
+package storefronttwiglinter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onlishop/onlishop-cli/internal/verifier/twiglinter"
+)
+
+func TestUnsafeOutputDetection(t *testing.T) {
+	cases := []struct {
+		name          string
+		content       string
+		expectedCount int
+	}{
+		{name: "Escaped output", content: `<div>{{ product.description }}</div>`, expectedCount: 0},
+		{name: "Raw variable", content: `<div>{{ product.description|raw }}</div>`, expectedCount: 1},
+		{name: "Raw with whitespace control", content: `<div>{{- product.description|raw -}}</div>`, expectedCount: 1},
+		{name: "Sanitized raw", content: `<div>{{ product.description|sw_sanitize|raw }}</div>`, expectedCount: 0},
+		{name: "Raw literal", content: `<div>{{ '<br>'|raw }}</div>`, expectedCount: 0},
+		{name: "Raw concatenation", content: `<div>{{ ('<b>' ~ name ~ '</b>')|raw }}</div>`, expectedCount: 1},
+		{name: "Raw format argument", content: `<div>{{ '<b>%s</b>'|format(name)|raw }}</div>`, expectedCount: 1},
+		{name: "Raw block", content: `<div>{{ block('content')|raw }}</div>`, expectedCount: 0},
+		{name: "Striptags raw", content: `<div>{{ text|striptags|raw }}</div>`, expectedCount: 0},
+		{name: "Striptags with allowed tags raw", content: `<div>{{ text|striptags('<a><img>')|raw }}</div>`, expectedCount: 1},
+		{name: "Raw in condition", content: `{% if show %}<div>{{ content|raw }}</div>{% endif %}`, expectedCount: 1},
+		{name: "Raw in attribute", content: `<div title="{{ title|raw }}"></div>`, expectedCount: 1},
+		{name: "Autoescape disabled", content: "{% block content %}\n{% autoescape false %}{{ content }}{% endautoescape %}\n{% endblock %}", expectedCount: 1},
+		{name: "Autoescape strategy", content: `{% autoescape 'js' %}{{ content }}{% endautoescape %}`, expectedCount: 0},
+		{name: "Static event handler", content: `<button onclick="history.back()">Back</button>`, expectedCount: 0},
+		{name: "Event handler with output", content: `<button onclick="buy('{{ product.id }}')">Buy</button>`, expectedCount: 1},
+		{name: "JavaScript escaped event handler", content: `<button onclick="buy('{{ product.id|e('js') }}')">Buy</button>`, expectedCount: 0},
+		{name: "Html escaped event handler", content: `<button onclick="buy('{{ product.id|e('html') }}')">Buy</button>`, expectedCount: 1},
+		{name: "Conditional event handler", content: `<button {% if active %}onclick="open('{{ url }}')"{% endif %}>Open</button>`, expectedCount: 1},
+		{name: "Data attribute", content: `<button data-product-id="{{ product.id }}">Buy</button>`, expectedCount: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks, err := twiglinter.RunCheckerOnString(UnsafeOutputCheck{}, tc.content)
+			assert.NoError(t, err)
+			assert.Len(t, checks, tc.expectedCount)
+		})
+	}
+}
+
+func TestUnsafeOutputLine(t *testing.T) {
+	checks, err := twiglinter.RunCheckerOnString(UnsafeOutputCheck{}, "<div>\n    {% autoescape false %}\n    {{ content }}\n    {% endautoescape %}\n</div>")
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, 2, checks[0].Line)
+	assert.Equal(t, "twig-linter/autoescape-disabled", checks[0].Identifier)
+}
@@ -313,6 +313,9 @@ type ConfigValidation struct {
 	Ignore []ConfigValidationIgnoreItem `yaml:"ignore,omitempty"`
 
 	IgnoreExtensions []ConfigValidationIgnoreExtension `yaml:"ignore_extensions,omitempty"`
+
+	// Configure the twig linter rules.
+	Rules []ConfigValidationRule `yaml:"rules,omitempty"`
 }
 
 // ConfigValidationIgnoreItem is used to ignore items from the validation.
@@ -325,6 +328,18 @@ type ConfigValidationIgnoreItem struct {
 	Message string `yaml:"message,omitempty"`
 }
 
+// ConfigValidationRule is used to disable a twig linter rule, change its severity or limit it to paths.
+type ConfigValidationRule struct {
+	// The ID of the rule, e.g. twig-linter/image-alt
+	Rule string `yaml:"rule"`
+	// Set to false to disable the rule.
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Overrides the severity of the results of the rule.
+	Severity string `yaml:"severity,omitempty" jsonschema:"enum=error,enum=warning"`
+	// Limits the rule to these paths relative to the project root, glob patterns or directories.
+	Paths []string `yaml:"paths,omitempty"`
+}
+
 type ConfigValidationIgnoreExtension struct {
 	// The name of the extension to ignore.
 	Name string `yaml:"name"`